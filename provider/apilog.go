@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// request and response attributes never written to the log
+var API_LOG_REDACT = map[string]bool{
+	"password":  true,
+	"passwd":    true,
+	"auth":      true,
+	"token":     true,
+	"sessionid": true,
+	"tls_psk":   true,
+
+	// snmp interface details and items
+	"community":             true,
+	"authpassphrase":        true,
+	"privpassphrase":        true,
+	"snmp_community":        true,
+	"snmpv3_authpassphrase": true,
+	"snmpv3_privpassphrase": true,
+
+	"ipmi_password": true,
+}
+
+// methods whose whole result is a secret
+var API_LOG_REDACT_RESULTS = map[string]bool{
+	"user.login": true,
+}
+
+const API_LOG_REDACTED = "<redacted>"
+
+// apiLogRedact replace secret attributes anywhere in a decoded json value
+func apiLogRedact(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		// secret macros, other objects with a type and value (e.g. triggers) have no macro attribute
+		if _, ok := t["macro"]; ok && fmt.Sprint(t["type"]) == MACRO_TYPES["secret"] {
+			if _, ok := t["value"]; ok {
+				t["value"] = API_LOG_REDACTED
+			}
+		}
+		for k, val := range t {
+			if API_LOG_REDACT[k] {
+				t[k] = API_LOG_REDACTED
+			} else {
+				t[k] = apiLogRedact(val)
+			}
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = apiLogRedact(val)
+		}
+	}
+	return v
+}
+
+// apiLogBody redacted form of a request or response body of an api method
+func apiLogBody(b []byte, method string) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		// not json, an error page from the frontend or a proxy
+		return string(b)
+	}
+
+	if obj, ok := v.(map[string]interface{}); ok {
+		if _, ok := obj["result"]; ok && API_LOG_REDACT_RESULTS[method] {
+			obj["result"] = API_LOG_REDACTED
+		}
+	}
+
+	// no html escaping, keeps the redaction marker readable
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(apiLogRedact(v)); err != nil {
+		return API_LOG_REDACTED
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// apiRequestBody copy of a request body, nil if it can not be read again
+func apiRequestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// apiRequestMethod api method named in a request body
+func apiRequestMethod(b []byte) string {
+	var req struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(b, &req); err != nil || req.Method == "" {
+		return "unknown"
+	}
+	return req.Method
+}
+
+// apiLogRequest log a request body
+func apiLogRequest(b []byte, method string) {
+	log.Debug("zabbix api request %s: %s", method, apiLogBody(b, method))
+}
+
+// apiLogResponse log a response body, the body is replaced so the caller can still read it
+func apiLogResponse(resp *http.Response, method string) error {
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	log.Debug("zabbix api response %s (%s): %s", method, resp.Status, apiLogBody(b, method))
+	return nil
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"sync"
+	"time"
+)
+
+// itemBatch items collected for a single item.create call
+type itemBatch struct {
+	items []itemObject
+	errs  []error
+	done  chan struct{}
+}
+
+// itemBatcher coalesce concurrent item creates on the same host into single api calls
+type itemBatcher struct {
+	sync.Mutex
+	window  time.Duration
+	pending map[string]*itemBatch
+}
+
+// newItemBatcher batcher collecting items for window before each create, nil when disabled
+func newItemBatcher(window time.Duration) *itemBatcher {
+	if window <= 0 {
+		return nil
+	}
+	return &itemBatcher{
+		window:  window,
+		pending: map[string]*itemBatch{},
+	}
+}
+
+// itemCreate create a single item, batched with others for the same host when enabled
+func itemCreate(m interface{}, item *itemObject) error {
+	meta := m.(*providerMeta)
+
+	if meta.ItemBatcher == nil {
+		items := []itemObject{*item}
+		err := apiRetryLocked(m, hostLockKey(item.HostID), func() error {
+			return itemsCreate(meta.API, items)
+		})
+		item.ItemID = items[0].ItemID
+		return err
+	}
+
+	return meta.ItemBatcher.create(m, item)
+}
+
+// create queue an item, waiting for its batch to be flushed
+func (b *itemBatcher) create(m interface{}, item *itemObject) error {
+	b.Lock()
+	batch, ok := b.pending[item.HostID]
+	if !ok {
+		batch = &itemBatch{done: make(chan struct{})}
+		b.pending[item.HostID] = batch
+		go b.flush(m, item.HostID, batch)
+	}
+	index := len(batch.items)
+	batch.items = append(batch.items, *item)
+	b.Unlock()
+
+	<-batch.done
+
+	item.ItemID = batch.items[index].ItemID
+	return batch.errs[index]
+}
+
+// flush create a batch once the window has passed
+func (b *itemBatcher) flush(m interface{}, hostid string, batch *itemBatch) {
+	time.Sleep(b.window)
+
+	b.Lock()
+	delete(b.pending, hostid)
+	b.Unlock()
+
+	defer close(batch.done)
+
+	api := m.(*providerMeta).API
+	batch.errs = make([]error, len(batch.items))
+
+	log.Debug("creating %d batched items on host %s", len(batch.items), hostid)
+
+	unlock := m.(*providerMeta).WriteLocks.lock(hostLockKey(hostid))
+	defer unlock()
+
+	err := apiRetry(m, func() error {
+		return itemsCreate(api, batch.items)
+	})
+	if err == nil || len(batch.items) == 1 {
+		for i := range batch.errs {
+			batch.errs[i] = err
+		}
+		return
+	}
+
+	// item.create is atomic, create one by one to attribute the error to the right item
+	log.Debug("batched item create failed, retrying individually: %s", err)
+	for i := range batch.items {
+		items := batch.items[i : i+1]
+		batch.errs[i] = apiRetry(m, func() error {
+			return itemsCreate(api, items)
+		})
+	}
+}
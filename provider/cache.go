@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// readCacheEntry single cached get response, done is closed once data or err is set
+type readCacheEntry struct {
+	done chan struct{}
+	data json.RawMessage
+	err  error
+}
+
+// readCache get responses of an api connection, keyed by method and params,
+// generation counts the writes, responses of reads overlapping one are not kept
+type readCache struct {
+	sync.Mutex
+	entries    map[string]*readCacheEntry
+	generation int
+}
+
+var readCaches = map[*zabbix.API]*readCache{}
+var readCachesLock sync.Mutex
+
+// readCacheEnable cache get calls made through apiGet on this api connection
+func readCacheEnable(api *zabbix.API) {
+	readCachesLock.Lock()
+	defer readCachesLock.Unlock()
+
+	readCaches[api] = &readCache{entries: map[string]*readCacheEntry{}}
+}
+
+// readCacheClear drop all cached responses, any write may change them
+func readCacheClear(api *zabbix.API) {
+	readCachesLock.Lock()
+	c := readCaches[api]
+	readCachesLock.Unlock()
+
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	c.entries = map[string]*readCacheEntry{}
+	c.generation++
+	c.Unlock()
+}
+
+// apiGet CallWithErrorParse for get methods, identical concurrent or repeated calls share one request
+func apiGet(api *zabbix.API, method string, params zabbix.Params, result interface{}) error {
+	readCachesLock.Lock()
+	c := readCaches[api]
+	readCachesLock.Unlock()
+
+	if c == nil {
+		return api.CallWithErrorParse(method, params, result)
+	}
+
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	key := method + string(b)
+
+	c.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &readCacheEntry{done: make(chan struct{})}
+		c.entries[key] = entry
+	}
+	generation := c.generation
+	c.Unlock()
+
+	if ok {
+		log.Trace("read cache hit for %s", key)
+	} else {
+		entry.err = api.CallWithErrorParse(method, params, &entry.data)
+
+		// errors are not cached, the next call tries again, nor are responses that may predate a write
+		c.Lock()
+		if (entry.err != nil || c.generation != generation) && c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.Unlock()
+		close(entry.done)
+	}
+
+	<-entry.done
+	if entry.err != nil {
+		return entry.err
+	}
+
+	return json.Unmarshal(entry.data, result)
+}
+
+// nameCache ids looked up by name, kept for the whole run as names rarely move between objects
+type nameCache struct {
+	sync.Mutex
+	ids map[string]string
+}
+
+// newNameCache empty name cache
+func newNameCache() *nameCache {
+	return &nameCache{ids: map[string]string{}}
+}
+
+// get cached id of a name
+func (c *nameCache) get(name string) (id string, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+	id, ok = c.ids[name]
+	return
+}
+
+// set cache the id of a name
+func (c *nameCache) set(name, id string) {
+	c.Lock()
+	defer c.Unlock()
+	c.ids[name] = id
+}
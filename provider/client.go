@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// status codes from the frontend, or a load balancer in front of it, worth retrying
+var API_RETRY_STATUS = map[int]bool{
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// apiReadMethod safe to resend, a write may already be committed when its response is lost
+func apiReadMethod(method string) bool {
+	return strings.HasSuffix(method, ".get") || method == "apiinfo.version"
+}
+
+// apiTransport http round tripper applying provider level settings to api requests
+type apiTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+	retries int
+	backoff time.Duration
+	slots   chan struct{}
+	debug   bool
+}
+
+// releaseBody response body calling release once closed, e.g. to return a concurrency slot
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close close the body and release the slot
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// RoundTrip wait for a free slot if concurrency is limited, then send the request
+func (t *apiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.slots == nil {
+		return t.send(req)
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := func() { <-t.slots }
+
+	resp, err := t.send(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// the slot is held until the caller has read the response
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// send add configured headers and pass the request on, retrying transient failures
+func (t *apiTransport) send(req *http.Request) (resp *http.Response, err error) {
+	if len(t.headers) > 0 {
+		// requests must not be modified, copy before adding headers
+		r := new(http.Request)
+		*r = *req
+		r.Header = make(http.Header, len(req.Header)+len(t.headers))
+		for k, v := range req.Header {
+			r.Header[k] = v
+		}
+		for k, v := range t.headers {
+			r.Header.Set(k, v)
+		}
+		req = r
+	}
+
+	body := apiRequestBody(req)
+	method := apiRequestMethod(body)
+	if t.debug {
+		apiLogRequest(body, method)
+	}
+
+	// a call lasts until its response is read, including any retries
+	start := time.Now()
+	defer func() {
+		record := func() { apiStats.record(method, time.Since(start)) }
+		if err != nil {
+			record()
+			return
+		}
+		resp.Body = &releaseBody{ReadCloser: resp.Body, release: record}
+	}()
+
+	wait := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil && t.debug {
+			if err = apiLogResponse(resp, method); err != nil {
+				resp = nil
+			}
+		}
+
+		retry := err != nil || API_RETRY_STATUS[resp.StatusCode]
+		if !retry || attempt >= t.retries || !apiReadMethod(method) || (req.Body != nil && req.GetBody == nil) {
+			// a proxy error page is not an api response, name the status instead of failing to decode it
+			if err == nil && retry {
+				resp.Body.Close()
+				return nil, fmt.Errorf("zabbix api %s returned %s after %d attempts", method, resp.Status, attempt+1)
+			}
+			return resp, err
+		}
+
+		if err != nil {
+			log.Debug("api request failed, retrying in %s: %s", wait, err)
+		} else {
+			log.Debug("api request returned %s, retrying in %s", resp.Status, wait)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+
+		if req.GetBody != nil {
+			r := new(http.Request)
+			*r = *req
+			if r.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+			req = r
+		}
+	}
+}
+
+// buildTLSConfig build tls settings from the provider ca and client certificate arguments
+func buildTLSConfig(d *schema.ResourceData) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: d.Get("tls_insecure").(bool),
+	}
+
+	var ca []byte
+	if file := d.Get("ca_file").(string); file != "" {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca_file: %s", err)
+		}
+		ca = b
+	} else if pem := d.Get("ca_pem").(string); pem != "" {
+		ca = []byte(pem)
+	}
+
+	if ca != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("no valid certificates found in ca bundle")
+		}
+		config.RootCAs = pool
+	}
+
+	certFile := d.Get("cert_file").(string)
+	keyFile := d.Get("key_file").(string)
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("cert_file and key_file must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// apiURL api endpoint of the url argument, api_jsonrpc.php is appended unless the path names a php script
+func apiURL(str string) (string, error) {
+	u, err := url.Parse(str)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse url: %s", err)
+	}
+
+	if !strings.HasSuffix(u.Path, ".php") {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/api_jsonrpc.php"
+	}
+
+	return u.String(), nil
+}
+
+// buildProxy pick the outbound proxy, the proxy_url argument or the environment
+func buildProxy(d *schema.ResourceData) (func(*http.Request) (*url.URL, error), error) {
+	str := d.Get("proxy_url").(string)
+	if str == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	u, err := url.Parse(str)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse proxy_url: %s", err)
+	}
+
+	return http.ProxyURL(u), nil
+}
+
+// buildClient build the http client used for api requests
+func buildClient(d *schema.ResourceData) (*http.Client, *apiTransport, error) {
+	tlsConfig, err := buildTLSConfig(d)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proxy, err := buildProxy(d)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headers := map[string]string{}
+	for k, v := range d.Get("http_headers").(map[string]interface{}) {
+		headers[http.CanonicalHeaderKey(k)] = v.(string)
+	}
+
+	// all requests go to one endpoint, keep enough idle connections to reuse them under parallel applies
+	idle := d.Get("max_idle_conns").(int)
+	transport := &apiTransport{
+		base: &http.Transport{
+			Proxy: proxy,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSClientConfig:     tlsConfig,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        idle,
+			MaxIdleConnsPerHost: idle,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		headers: headers,
+		retries: d.Get("retries").(int),
+		backoff: time.Duration(d.Get("retry_backoff").(int)) * time.Second,
+		debug:   d.Get("debug_api").(bool),
+	}
+
+	if max := d.Get("max_api_concurrency").(int); max > 0 {
+		transport.slots = make(chan struct{}, max)
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(d.Get("timeout").(int)) * time.Second,
+	}
+
+	return client, transport, nil
+}
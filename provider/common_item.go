@@ -1,11 +1,13 @@
 package provider
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/tpretz/go-zabbix-api"
@@ -37,11 +39,21 @@ var ITEM_VALUE_TYPES_ARR = []string{
 // common schema elements for all item types
 var itemCommonSchema = map[string]*schema.Schema{
 	"hostid": &schema.Schema{
-		Type:         schema.TypeString,
-		Required:     true,
-		ForceNew:     true,
-		Description:  "Host ID",
-		ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ForceNew:      true,
+		Description:   "Host ID",
+		ValidateFunc:  validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+		ConflictsWith: []string{"host"},
+		AtLeastOneOf:  []string{"hostid", "host"},
+	},
+	"host": &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Description:   "Host or template technical name, resolved to hostid",
+		ValidateFunc:  validation.StringIsNotWhiteSpace,
+		ConflictsWith: []string{"hostid"},
 	},
 	"key": &schema.Schema{
 		Type:         schema.TypeString,
@@ -64,14 +76,14 @@ var itemCommonSchema = map[string]*schema.Schema{
 	"preprocessor": itemPreprocessorSchema,
     "applications":  &schema.Schema{
         Type:        schema.TypeSet,
-        Description: "IDs of the applications to add the item to",
+        Description: "IDs of the applications to add the item to (zabbix < 5.4)",
         Optional:    true,
         Elem: &schema.Schema{
             Type:         schema.TypeString,
             ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
         },
     },
-
+	"tag": tagSetSchema,
 }
 
 // Delay schema
@@ -79,9 +91,11 @@ var itemDelaySchema = map[string]*schema.Schema{
 	"delay": &schema.Schema{
 		Type:         schema.TypeString,
 		Optional:     true,
-		ValidateFunc: validation.StringIsNotWhiteSpace,
+		ValidateFunc: validateItemDelay,
 		Default:      "1m",
 		Description:  "Item Delay period",
+		// 60, 60s and 1m are equivalent
+		DiffSuppressFunc: intervalDiffSuppress,
 	},
 }
 
@@ -134,6 +148,111 @@ var itemPreprocessorSchema = &schema.Schema{
 	},
 }
 
+// plan time reference checks shared by all item types
+var itemReferenceCheck = customdiff.All(
+	itemHostDiff,
+	referenceCheck(map[string]string{
+		"hostid":       "host",
+		"applications": "application",
+	}),
+)
+
+// itemHostDiff resolve host to hostid at plan time, moving to another host replaces the item
+func itemHostDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("host") || !d.NewValueKnown("host") || d.Get("host").(string) == "" {
+		return nil
+	}
+
+	id, err := hostIdLookup(m.(*providerMeta), d.Get("host").(string))
+	if err != nil {
+		return err
+	}
+
+	// not created yet, resolved again on apply
+	if id == "" {
+		return d.SetNewComputed("hostid")
+	}
+	if id != d.Get("hostid").(string) {
+		return d.SetNew("hostid", id)
+	}
+	return nil
+}
+
+// itemHostID host id of an item, resolving host when configured
+func itemHostID(d *schema.ResourceData, meta *providerMeta) (string, error) {
+	name := d.Get("host").(string)
+	if name == "" {
+		return d.Get("hostid").(string), nil
+	}
+
+	id, err := hostIdLookup(meta, name)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", fmt.Errorf("host %s does not exist", name)
+	}
+	return id, nil
+}
+
+// item attributes read by the item resources
+var ITEM_OUTPUT_FIELDS = []string{
+	"itemid", "hostid", "key_", "name", "type", "value_type", "delay", "interfaceid",
+	"url", "request_method", "post_type", "posts", "status_codes", "timeout", "verify_host", "verify_peer",
+	"snmp_oid", "master_itemid", "flags", "templateid",
+}
+
+// snmp item attributes moved to host interfaces in zabbix 5.0
+var ITEM_OUTPUT_FIELDS_SNMP = []string{
+	"snmp_community", "snmpv3_authpassphrase", "snmpv3_authprotocol", "snmpv3_contextname",
+	"snmpv3_privpassphrase", "snmpv3_privprotocol", "snmpv3_securitylevel", "snmpv3_securityname",
+}
+
+// itemOutputFields item.get output valid for the server version
+func itemOutputFields(v serverVersion) []string {
+	fields := append([]string{}, ITEM_OUTPUT_FIELDS...)
+	if !v.AtLeast(5, 0) {
+		fields = append(fields, ITEM_OUTPUT_FIELDS_SNMP...)
+	}
+	return fields
+}
+
+// itemApplicationIDs application ids, also read from selectApplications objects
+type itemApplicationIDs []string
+
+// UnmarshalJSON accept both id strings and application objects
+func (a *itemApplicationIDs) UnmarshalJSON(b []byte) error {
+	ids := []string{}
+	if err := json.Unmarshal(b, &ids); err == nil {
+		*a = ids
+		return nil
+	}
+
+	objects := []struct {
+		ApplicationID string `json:"applicationid"`
+	}{}
+	if err := json.Unmarshal(b, &objects); err != nil {
+		return err
+	}
+	for _, v := range objects {
+		ids = append(ids, v.ApplicationID)
+	}
+	*a = ids
+
+	return nil
+}
+
+// itemObject library item struct, extended with tags (zabbix 5.4+)
+type itemObject struct {
+	zabbix.Item
+	ApplicationIds itemApplicationIDs `json:"applications,omitempty"`
+	Tags           *zabbix.Tags       `json:"tags,omitempty"`
+
+	// read only
+	Flags      string `json:"flags,omitempty"`
+	TemplateID string `json:"templateid,omitempty"`
+}
+
 // Function signature for context manipulation
 type ItemHandler func(*schema.ResourceData, *zabbix.Item)
 
@@ -160,45 +279,54 @@ func itemGetReadWrapper(r ItemHandler) schema.ReadFunc {
 
 // Create Item Resource Handler
 func resourceItemCreate(d *schema.ResourceData, m interface{}, c ItemHandler, r ItemHandler) error {
-	api := m.(*zabbix.API)
+	meta := m.(*providerMeta)
 
-	item := buildItemObject(d)
+	item, err := buildItemObject(d, meta)
+	if err != nil {
+		return err
+	}
 
 	// run custom function
-	c(d, item)
+	c(d, &item.Item)
 
 	log.Trace("preparing item object for create/update: %#v", item)
 
-	items := []zabbix.Item{*item}
-
-	err := api.ItemsCreate(items)
-
-	if err != nil {
+	if err := itemCreate(m, item); err != nil {
 		return err
 	}
 
-	log.Trace("created item: %+v", items[0])
+	log.Trace("created item: %+v", item)
 
-	d.SetId(items[0].ItemID)
+	d.SetId(item.ItemID)
 
 	return resourceItemRead(d, m, r)
 }
 
 // Update Item Resource Handler
 func resourceItemUpdate(d *schema.ResourceData, m interface{}, c ItemHandler, r ItemHandler) error {
-	api := m.(*zabbix.API)
+	meta := m.(*providerMeta)
+	api := meta.API
+
+	if err := objectEditable(api, "item", d.Id()); err != nil {
+		return err
+	}
 
-	item := buildItemObject(d)
+	item, err := buildItemObject(d, meta)
+	if err != nil {
+		return err
+	}
 	item.ItemID = d.Id()
 
 	// run custom function
-	c(d, item)
+	c(d, &item.Item)
 
 	log.Trace("preparing item object for create/update: %#v", item)
 
-	items := []zabbix.Item{*item}
+	items := []itemObject{*item}
 
-	err := api.ItemsUpdate(items)
+	err = apiRetryLocked(m, hostLockKey(item.HostID), func() error {
+		return itemsUpdate(api, items)
+	})
 
 	if err != nil {
 		return err
@@ -209,14 +337,23 @@ func resourceItemUpdate(d *schema.ResourceData, m interface{}, c ItemHandler, r
 
 // Read Item Resource Handler
 func resourceItemRead(d *schema.ResourceData, m interface{}, r ItemHandler) error {
-	api := m.(*zabbix.API)
+	meta := m.(*providerMeta)
+	api := meta.API
 
 	log.Debug("Lookup of item with id %s", d.Id())
 
-	items, err := api.ItemsGet(zabbix.Params{
+	params := zabbix.Params{
 		"itemids":             []string{d.Id()},
-		"selectPreprocessing": "extend",
-	})
+		"output":              itemOutputFields(meta.Version),
+		"selectPreprocessing": []string{"type", "params", "error_handler", "error_handler_params"},
+	}
+	if meta.Version.AtLeast(5, 4) {
+		params["selectTags"] = "extend"
+	} else {
+		params["selectApplications"] = []string{"applicationid"}
+	}
+
+	items, err := itemsGet(api, params)
 
 	if err != nil {
 		return err
@@ -233,35 +370,70 @@ func resourceItemRead(d *schema.ResourceData, m interface{}, r ItemHandler) erro
 
 	log.Debug("Got item: %+v", item)
 
+	if err := objectOrigin("item", item.ItemID, item.Flags, item.TemplateID); err != nil {
+		log.Warn("%s", err)
+	}
+
 	d.SetId(item.ItemID)
 	d.Set("hostid", item.HostID)
 	d.Set("key", item.Key)
 	d.Set("name", item.Name)
 	d.Set("valuetype", ITEM_VALUE_TYPES_REV[item.ValueType])
-	d.Set("preprocessor", flattenItemPreprocessors(item))
+	d.Set("preprocessor", flattenItemPreprocessors(item.Item))
 	appSet := schema.NewSet(schema.HashString, []interface{}{})
 	for _, v := range item.ApplicationIds {
-	  appSet.Add(v)
-        }
+		appSet.Add(v)
+	}
 	d.Set("applications", appSet)
 
+	// provider default tags are not part of the resource config
+	tags := zabbix.Tags{}
+	if item.Tags != nil {
+		tags = *item.Tags
+	}
+	d.Set("tag", flattenTags(tagsWithoutDefaults(tags, meta.DefaultTags, tagGenerate(d))))
+
 	// run custom
-	r(d, &item)
+	r(d, &item.Item)
 
 	return nil
 }
 
 // Build the base Item Object
-func buildItemObject(d *schema.ResourceData) *zabbix.Item {
-	item := zabbix.Item{
-		Key:       d.Get("key").(string),
-		HostID:    d.Get("hostid").(string),
-		Name:      d.Get("name").(string),
-		ValueType: ITEM_VALUE_TYPES[d.Get("valuetype").(string)],
+func buildItemObject(d *schema.ResourceData, meta *providerMeta) (*itemObject, error) {
+	hostid, err := itemHostID(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	item := itemObject{
+		Item: zabbix.Item{
+			Key:       d.Get("key").(string),
+			HostID:    hostid,
+			Name:      d.Get("name").(string),
+			ValueType: ITEM_VALUE_TYPES[d.Get("valuetype").(string)],
+		},
 	}
 	item.Preprocessors = itemGeneratePreprocessors(d)
 
-	return &item
+	// applications were replaced by tags in 5.4, only one mechanism is accepted by a server
+	apps := buildApplicationIds(d.Get("applications").(*schema.Set))
+	tags := tagGenerate(d)
+
+	if meta.Version.AtLeast(5, 4) {
+		if len(apps) > 0 {
+			return nil, fmt.Errorf("item applications were removed in zabbix 5.4, server is %s, use tag blocks instead", meta.Version)
+		}
+		tags = tagsWithDefaults(tags, meta.DefaultTags)
+		item.Tags = &tags
+	} else {
+		if len(tags) > 0 {
+			return nil, fmt.Errorf("item tags require zabbix 5.4 or later, server is %s, use applications instead", meta.Version)
+		}
+		item.ApplicationIds = apps
+	}
+
+	return &item, nil
 }
 
 // Generate preprocessor objects
@@ -304,8 +476,46 @@ func flattenItemPreprocessors(item zabbix.Item) []interface{} {
 	return val
 }
 
+// itemsGet query items, unlike the library also returning tags
+func itemsGet(api *zabbix.API, params zabbix.Params) (items []itemObject, err error) {
+	if _, ok := params["output"]; !ok {
+		params["output"] = "extend"
+	}
+	err = apiGet(api, "item.get", params, &items)
+	return
+}
+
+// itemsCreate create items, populating the generated item ids
+func itemsCreate(api *zabbix.API, items []itemObject) error {
+	response, err := api.CallWithError("item.create", items)
+
+	if err != nil {
+		return err
+	}
+
+	result := response.Result.(map[string]interface{})
+	itemids := result["itemids"].([]interface{})
+	for i, id := range itemids {
+		items[i].ItemID = id.(string)
+	}
+
+	return nil
+}
+
+// itemsUpdate update items
+func itemsUpdate(api *zabbix.API, items []itemObject) error {
+	_, err := api.CallWithError("item.update", items)
+	return err
+}
+
 // Delete Item Resource Handler
 func resourceItemDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
-	return api.ItemsDeleteByIds([]string{d.Id()})
+	api := m.(*providerMeta).API
+
+	if err := objectEditable(api, "item", d.Id()); err != nil {
+		return err
+	}
+	return apiRetryLocked(m, hostLockKey(d.Get("hostid").(string)), func() error {
+		return api.ItemsDeleteByIds([]string{d.Id()})
+	})
 }
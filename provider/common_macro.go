@@ -2,12 +2,39 @@ package provider
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/tpretz/go-zabbix-api"
 )
 
+// macro type conversions
+var MACRO_TYPES = map[string]string{
+	"text":   "0",
+	"secret": "1",
+	"vault":  "2",
+}
+var MACRO_TYPES_REV = map[string]string{}
+var MACRO_TYPES_ARR = []string{}
+
+// generate the above structures
+var _ = func() bool {
+	for k, v := range MACRO_TYPES {
+		MACRO_TYPES_REV[v] = k
+		MACRO_TYPES_ARR = append(MACRO_TYPES_ARR, k)
+	}
+	return false
+}()
+
+// macroObject library macro struct, extended with type and description
+type macroObject struct {
+	zabbix.Macro
+	Type        string  `json:"type,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+type macroObjects []macroObject
+
 // macro list schema
 var macroListSchema = &schema.Schema{
 	Type:     schema.TypeList,
@@ -27,25 +54,50 @@ var macroListSchema = &schema.Schema{
 			"value": &schema.Schema{
 				Type:         schema.TypeString,
 				Required:     true,
+				Sensitive:    true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
-				Description:  "Macro Value",
+				Description:  "Macro Value, vault path for vault macros",
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "text",
+				Description:  "Macro type, one of: " + strings.Join(MACRO_TYPES_ARR, ", "),
+				ValidateFunc: validation.StringInSlice(MACRO_TYPES_ARR, false),
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Macro description",
 			},
 		},
 	},
 }
 
 // macroGenerate build macro structs from terraform inputs
-func macroGenerate(d *schema.ResourceData) (macros zabbix.Macros) {
+func macroGenerate(d *schema.ResourceData) (macros macroObjects) {
 	macroCount := d.Get("macro.#").(int)
-	macros = make(zabbix.Macros, macroCount)
+	macros = make(macroObjects, macroCount)
 
 	for i := 0; i < macroCount; i++ {
 		prefix := fmt.Sprintf("macro.%d.", i)
 
-		macros[i] = zabbix.Macro{
-			MacroName: d.Get(prefix + "name").(string),
-			Value:     d.Get(prefix + "value").(string),
-			MacroID:   d.Get(prefix + "id").(string),
+		macros[i] = macroObject{
+			Macro: zabbix.Macro{
+				MacroName: d.Get(prefix + "name").(string),
+				Value:     d.Get(prefix + "value").(string),
+				MacroID:   d.Get(prefix + "id").(string),
+			},
+		}
+
+		// description is only understood by zabbix 4.4+, omit it unless set or being cleared
+		if v := d.Get(prefix + "description").(string); v != "" || d.HasChange(prefix+"description") {
+			macros[i].Description = &v
+		}
+
+		// type is only understood by zabbix 5.0+, omit the default unless changing back to it
+		if t := d.Get(prefix + "type").(string); t != "text" || d.HasChange(prefix+"type") {
+			macros[i].Type = MACRO_TYPES[t]
 		}
 	}
 
@@ -53,14 +105,66 @@ func macroGenerate(d *schema.ResourceData) (macros zabbix.Macros) {
 }
 
 // flattenMacros convert response to terraform input
-func flattenMacros(list zabbix.Macros) []interface{} {
+func flattenMacros(list macroObjects, d *schema.ResourceData) []interface{} {
+	// secret values are never returned, carry over the known value
+	known := map[string]string{}
+	for i := 0; i < d.Get("macro.#").(int); i++ {
+		prefix := fmt.Sprintf("macro.%d.", i)
+		known[d.Get(prefix+"name").(string)] = d.Get(prefix + "value").(string)
+	}
+
+	list = macroSort(list, d)
+
 	val := make([]interface{}, len(list))
 	for i := 0; i < len(list); i++ {
+		value := list[i].Value
+		if list[i].Type == MACRO_TYPES["secret"] && value == "" {
+			value = known[list[i].MacroName]
+		}
+
+		macroType := MACRO_TYPES_REV[list[i].Type]
+		if macroType == "" {
+			macroType = "text"
+		}
+
+		description := ""
+		if list[i].Description != nil {
+			description = *list[i].Description
+		}
+
 		val[i] = map[string]interface{}{
-			"name":  list[i].MacroName,
-			"value": list[i].Value,
-			"id":    list[i].MacroID,
+			"name":        list[i].MacroName,
+			"value":       value,
+			"id":          list[i].MacroID,
+			"type":        macroType,
+			"description": description,
 		}
 	}
 	return val
 }
+
+// macroSort order macros as they are in state, the api returns them in id order
+func macroSort(list macroObjects, d *schema.ResourceData) macroObjects {
+	byName := map[string]macroObject{}
+	for _, v := range list {
+		byName[v.MacroName] = v
+	}
+
+	sorted := macroObjects{}
+	for i := 0; i < d.Get("macro.#").(int); i++ {
+		name := d.Get(fmt.Sprintf("macro.%d.name", i)).(string)
+		if v, ok := byName[name]; ok {
+			sorted = append(sorted, v)
+			delete(byName, name)
+		}
+	}
+
+	// anything new, in api order
+	for _, v := range list {
+		if _, ok := byName[v.MacroName]; ok {
+			sorted = append(sorted, v)
+		}
+	}
+
+	return sorted
+}
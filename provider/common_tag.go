@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// tag set schema
+var tagSetSchema = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Tag Key",
+			},
+			"value": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Tag Value",
+			},
+		},
+	},
+}
+
+// tag set schema of data sources, tags given filter the lookup
+var tagSetDataSchema = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	Computed: true,
+	Elem:     tagSetSchema.Elem,
+}
+
+// tag set schema of data sources not filtering by tag
+var tagSetComputedSchema = &schema.Schema{
+	Type:     schema.TypeSet,
+	Computed: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Tag Key",
+			},
+			"value": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Tag Value",
+			},
+		},
+	},
+}
+
+// tagGenerate build tag structs from terraform inputs
+func tagGenerate(d *schema.ResourceData) zabbix.Tags {
+	return tagGenerateSet(d.Get("tag").(*schema.Set))
+}
+
+// tagGenerateSet build tag structs from a tag set
+func tagGenerateSet(s *schema.Set) (tags zabbix.Tags) {
+	set := s.List()
+	tags = make(zabbix.Tags, len(set))
+
+	for i := 0; i < len(set); i++ {
+		current := set[i].(map[string]interface{})
+		tags[i] = zabbix.Tag{
+			Tag:   current["key"].(string),
+			Value: current["value"].(string),
+		}
+	}
+
+	return
+}
+
+// flattenTags convert response to terraform input
+func flattenTags(list zabbix.Tags) *schema.Set {
+	set := schema.NewSet(func(i interface{}) int {
+		m := i.(map[string]interface{})
+		return hashcode.String(m["key"].(string) + "V" + m["value"].(string))
+	}, []interface{}{})
+	for i := 0; i < len(list); i++ {
+		set.Add(map[string]interface{}{
+			"key":   list[i].Tag,
+			"value": list[i].Value,
+		})
+	}
+	return set
+}
+
+// tagFilterGenerate build a get request tag filter, matching every tag exactly
+func tagFilterGenerate(tags zabbix.Tags) []map[string]interface{} {
+	filter := make([]map[string]interface{}, len(tags))
+	for i, t := range tags {
+		// operator 1 is equals
+		filter[i] = map[string]interface{}{
+			"tag":      t.Tag,
+			"value":    t.Value,
+			"operator": "1",
+		}
+	}
+	return filter
+}
+
+// tagsWithDefaults merge provider default tags in, a tag key set on the resource replaces the default
+func tagsWithDefaults(tags zabbix.Tags, defaults zabbix.Tags) zabbix.Tags {
+	keys := map[string]bool{}
+	for _, t := range tags {
+		keys[t.Tag] = true
+	}
+
+	merged := append(zabbix.Tags{}, tags...)
+	for _, t := range defaults {
+		if !keys[t.Tag] {
+			merged = append(merged, t)
+		}
+	}
+
+	return merged
+}
+
+// tagsWithoutDefaults hide provider default tags, unless the key is also set on the resource
+func tagsWithoutDefaults(tags zabbix.Tags, defaults zabbix.Tags, own zabbix.Tags) zabbix.Tags {
+	keys := map[string]bool{}
+	for _, t := range own {
+		keys[t.Tag] = true
+	}
+	hidden := map[zabbix.Tag]bool{}
+	for _, t := range defaults {
+		if !keys[t.Tag] {
+			hidden[t] = true
+		}
+	}
+
+	list := zabbix.Tags{}
+	for _, t := range tags {
+		if !hidden[t] {
+			list = append(list, t)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// object flags value of objects created by low level discovery
+const FLAGS_DISCOVERED = "4"
+
+// objectOrigin explain why the api refuses changes to an object, nil for plain objects
+func objectOrigin(kind, id, flags, templateid string) error {
+	if flags == FLAGS_DISCOVERED {
+		return fmt.Errorf("%s %s was created by low level discovery and can not be changed, change its prototype instead", kind, id)
+	}
+	if templateid != "" && templateid != "0" {
+		return fmt.Errorf("%s %s is inherited from template %s %s and can not be changed, change it on the template instead", kind, id, kind, templateid)
+	}
+	return nil
+}
+
+// objectEditable check an object is neither discovered nor inherited before writing to it
+func objectEditable(api *zabbix.API, kind, id string) error {
+	ref := REFERENCE_TYPES[kind]
+
+	// hosts are not inherited, applications may be inherited from several templates
+	output := []string{"flags", "templateid"}
+	switch kind {
+	case "host":
+		output = []string{"flags"}
+	case "application":
+		output = []string{"flags", "templateids"}
+	}
+
+	params := zabbix.Params{
+		"output":  output,
+		ref.Param: []string{id},
+	}
+	for k, v := range ref.Extra {
+		params[k] = v
+	}
+
+	var found []map[string]interface{}
+	if err := apiGet(api, ref.Method, params, &found); err != nil {
+		return err
+	}
+
+	// missing objects are left to the api to report
+	if len(found) < 1 {
+		return nil
+	}
+
+	flags, _ := found[0]["flags"].(string)
+	templateid, _ := found[0]["templateid"].(string)
+	if ids, _ := found[0]["templateids"].([]interface{}); len(ids) > 0 {
+		templateid, _ = ids[0].(string)
+	}
+
+	return objectOrigin(kind, id, flags, templateid)
+}
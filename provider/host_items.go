@@ -0,0 +1,309 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// item types available as inline host items
+var HOST_ITEM_TYPES = map[string]zabbix.ItemType{
+	"agent":        zabbix.ZabbixAgent,
+	"agent_active": zabbix.ZabbixAgentActive,
+	"snmp":         zabbix.SNMPv2Agent,
+}
+var HOST_ITEM_TYPES_REV = map[zabbix.ItemType]string{}
+var HOST_ITEM_TYPES_ARR = []string{}
+
+// snmp agent item type of zabbix 5.0+, replacing the per version snmp types
+const HOST_ITEM_SNMP_AGENT zabbix.ItemType = 20
+
+// generate the above structures
+var _ = func() bool {
+	for k, v := range HOST_ITEM_TYPES {
+		HOST_ITEM_TYPES_REV[v] = k
+		HOST_ITEM_TYPES_ARR = append(HOST_ITEM_TYPES_ARR, k)
+	}
+	HOST_ITEM_TYPES_REV[HOST_ITEM_SNMP_AGENT] = "snmp"
+	return false
+}()
+
+// inline item blocks of a host, for a few simple items without separate resources
+var hostItemSchema = &schema.Schema{
+	Type:        schema.TypeList,
+	Optional:    true,
+	Description: "Simple agent and snmp items managed with the host",
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"itemid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Item KEY",
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Item Name",
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "agent",
+				ValidateFunc: validation.StringInSlice(HOST_ITEM_TYPES_ARR, false),
+				Description:  "Item type, one of: " + strings.Join(HOST_ITEM_TYPES_ARR, ", "),
+			},
+			"valuetype": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "unsigned",
+				ValidateFunc: validation.StringInSlice(ITEM_VALUE_TYPES_ARR, false),
+				Description:  "Item Value Type, one of: " + strings.Join(ITEM_VALUE_TYPES_ARR, ", "),
+			},
+			"delay": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "1m",
+				ValidateFunc:     validateItemDelay,
+				DiffSuppressFunc: intervalDiffSuppress,
+				Description:      "Item Delay period",
+			},
+			"snmp_oid": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SNMP OID, snmp items only",
+			},
+		},
+	},
+}
+
+// hostItemInterfaces main interface ids of a host by type
+func hostItemInterfaces(api *zabbix.API, hostid string) (map[zabbix.InterfaceType]string, error) {
+	var interfaces hostInterfaces
+	err := apiGet(api, "hostinterface.get", zabbix.Params{
+		"output":  []string{"interfaceid", "type", "main"},
+		"hostids": []string{hostid},
+	}, &interfaces)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[zabbix.InterfaceType]string{}
+	for _, v := range interfaces {
+		if v.Main == "1" {
+			ids[v.Type] = v.InterfaceID
+		}
+	}
+	return ids, nil
+}
+
+// buildHostItem item object of an inline item block, attached to the main interface of its type
+func buildHostItem(d *schema.ResourceData, meta *providerMeta, block map[string]interface{}, interfaces map[zabbix.InterfaceType]string) (*itemObject, error) {
+	item := itemObject{
+		Item: zabbix.Item{
+			HostID:    d.Id(),
+			Key:       block["key"].(string),
+			Name:      block["name"].(string),
+			Type:      HOST_ITEM_TYPES[block["type"].(string)],
+			ValueType: ITEM_VALUE_TYPES[block["valuetype"].(string)],
+			Delay:     block["delay"].(string),
+		},
+	}
+
+	var ifaceType zabbix.InterfaceType
+	switch item.Type {
+	case zabbix.ZabbixAgent:
+		ifaceType = zabbix.Agent
+	case zabbix.SNMPv2Agent:
+		ifaceType = zabbix.SNMP
+		item.SNMPOid = block["snmp_oid"].(string)
+		if item.SNMPOid == "" {
+			return nil, fmt.Errorf("item %s: snmp items require snmp_oid", item.Key)
+		}
+		// the community moved to the host interface in 5.0
+		if meta.Version.AtLeast(5, 0) {
+			item.Type = HOST_ITEM_SNMP_AGENT
+		} else {
+			item.SNMPCommunity = "{$SNMP_COMMUNITY}"
+		}
+	}
+	if ifaceType != "" {
+		item.InterfaceID = interfaces[ifaceType]
+		if item.InterfaceID == "" {
+			return nil, fmt.Errorf("item %s: host has no main %s interface", item.Key, HOST_IFACE_TYPES_REV[ifaceType])
+		}
+	}
+
+	// default tags mark inline items as managed for purge_unmanaged
+	if meta.Version.AtLeast(5, 4) {
+		tags := tagsWithDefaults(zabbix.Tags{}, meta.DefaultTags)
+		item.Tags = &tags
+	}
+
+	return &item, nil
+}
+
+// hostItemsApply create, update and delete inline items to match the item blocks
+func hostItemsApply(d *schema.ResourceData, m interface{}) error {
+	if !d.HasChange("item") {
+		return nil
+	}
+	meta := m.(*providerMeta)
+	api := meta.API
+
+	o, n := d.GetChange("item")
+
+	// existing items are matched by key
+	existing := map[string]string{}
+	oldKeys := []string{}
+	for _, v := range o.([]interface{}) {
+		block := v.(map[string]interface{})
+		existing[block["key"].(string)] = block["itemid"].(string)
+		oldKeys = append(oldKeys, block["key"].(string))
+	}
+
+	newKeys := map[string]bool{}
+	for _, v := range n.([]interface{}) {
+		newKeys[v.(map[string]interface{})["key"].(string)] = true
+	}
+
+	interfaces, err := hostItemInterfaces(api, d.Id())
+	if err != nil {
+		return err
+	}
+
+	list := n.([]interface{})
+	seen := map[string]bool{}
+	creates := []itemObject{}
+	createIndex := []int{}
+	updates := []itemObject{}
+
+	for i, v := range list {
+		block := v.(map[string]interface{})
+		key := block["key"].(string)
+		if seen[key] {
+			return fmt.Errorf("item %s is defined more than once", key)
+		}
+		seen[key] = true
+
+		item, err := buildHostItem(d, meta, block, interfaces)
+		if err != nil {
+			return err
+		}
+
+		// a key changed in place keeps its item, and history, instead of replacing it
+		if _, ok := existing[key]; !ok && i < len(oldKeys) && !newKeys[oldKeys[i]] {
+			if id := existing[oldKeys[i]]; id != "" {
+				log.Warn("inline item %s of host %s renamed to %s, keeping its history", oldKeys[i], d.Id(), key)
+				existing[key] = id
+				delete(existing, oldKeys[i])
+			}
+		}
+
+		if id := existing[key]; id != "" {
+			item.ItemID = id
+			block["itemid"] = id
+			updates = append(updates, *item)
+		} else {
+			creates = append(creates, *item)
+			createIndex = append(createIndex, i)
+		}
+		delete(existing, key)
+	}
+
+	deletes := []string{}
+	for _, id := range existing {
+		if id != "" {
+			deletes = append(deletes, id)
+		}
+	}
+
+	lockKey := hostLockKey(d.Id())
+	if len(deletes) > 0 {
+		err := apiRetryLocked(m, lockKey, func() error {
+			return api.ItemsDeleteByIds(deletes)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(updates) > 0 {
+		err := apiRetryLocked(m, lockKey, func() error {
+			return itemsUpdate(api, updates)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(creates) > 0 {
+		err := apiRetryLocked(m, lockKey, func() error {
+			return itemsCreate(api, creates)
+		})
+		if err != nil {
+			return err
+		}
+		for i, item := range creates {
+			list[createIndex[i]].(map[string]interface{})["itemid"] = item.ItemID
+		}
+	}
+
+	return d.Set("item", list)
+}
+
+// hostItemsRead refresh inline items in state, items removed outside terraform are dropped
+func hostItemsRead(d *schema.ResourceData, m interface{}) error {
+	list := d.Get("item").([]interface{})
+	if len(list) < 1 {
+		return nil
+	}
+	meta := m.(*providerMeta)
+
+	ids := []string{}
+	for _, v := range list {
+		if id := v.(map[string]interface{})["itemid"].(string); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	items, err := itemsGet(meta.API, zabbix.Params{
+		"output":  itemOutputFields(meta.Version),
+		"itemids": ids,
+		"hostids": []string{d.Id()},
+	})
+	if err != nil {
+		return err
+	}
+
+	byId := map[string]itemObject{}
+	for _, v := range items {
+		byId[v.ItemID] = v
+	}
+
+	// keep the configured order
+	out := []interface{}{}
+	for _, v := range list {
+		item, ok := byId[v.(map[string]interface{})["itemid"].(string)]
+		if !ok {
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"itemid":    item.ItemID,
+			"key":       item.Key,
+			"name":      item.Name,
+			"type":      HOST_ITEM_TYPES_REV[item.Type],
+			"valuetype": ITEM_VALUE_TYPES_REV[item.ValueType],
+			"delay":     item.Delay,
+			"snmp_oid":  item.SNMPOid,
+		})
+	}
+
+	return d.Set("item", out)
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+var importNumericID = regexp.MustCompile("^[0-9]+$")
+
+// importByName importer accepting a numeric id, or attribute filters in query form
+// e.g. host=web-01 or hostid=10084&name=CPU, values may be url escaped
+func importByName(method, idField string, fields []string) *schema.ResourceImporter {
+	allowed := map[string]bool{}
+	for _, f := range fields {
+		allowed[f] = true
+	}
+
+	return &schema.ResourceImporter{
+		State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			if importNumericID.MatchString(d.Id()) {
+				return []*schema.ResourceData{d}, nil
+			}
+
+			values, err := url.ParseQuery(d.Id())
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse import id %s: %s", d.Id(), err)
+			}
+
+			filter := map[string]interface{}{}
+			for k, v := range values {
+				if !allowed[k] || len(v) != 1 {
+					return nil, fmt.Errorf("import id must be numeric or filters on: %s", strings.Join(fields, ", "))
+				}
+				filter[k] = v[0]
+			}
+			if len(filter) < 1 {
+				return nil, fmt.Errorf("import id must be numeric or filters on: %s", strings.Join(fields, ", "))
+			}
+
+			var found []map[string]interface{}
+			err = m.(*providerMeta).API.CallWithErrorParse(method, zabbix.Params{
+				"output": []string{idField},
+				"filter": filter,
+			}, &found)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(found) != 1 {
+				return nil, fmt.Errorf("expected one object matching %s, found %d", d.Id(), len(found))
+			}
+
+			log.Debug("resolved import id %s to %v", d.Id(), found[0][idField])
+			d.SetId(fmt.Sprintf("%v", found[0][idField]))
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+// itemImporter importer accepting a numeric id, or the item key relative to its host
+// e.g. web-01/system.cpu.load[all,avg1], host names can not contain a /
+func itemImporter() *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			if importNumericID.MatchString(d.Id()) {
+				return []*schema.ResourceData{d}, nil
+			}
+
+			parts := strings.SplitN(d.Id(), "/", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return nil, fmt.Errorf("import id must be numeric or <host>/<key>, got %s", d.Id())
+			}
+
+			items, err := m.(*providerMeta).API.ItemsGet(zabbix.Params{
+				"output": []string{"itemid"},
+				"host":   parts[0],
+				"filter": map[string]interface{}{
+					"key_": parts[1],
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if len(items) != 1 {
+				return nil, fmt.Errorf("expected one item matching %s, found %d", d.Id(), len(items))
+			}
+
+			log.Debug("resolved import id %s to %s", d.Id(), items[0].ItemID)
+			d.SetId(items[0].ItemID)
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
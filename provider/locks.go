@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"sync"
+)
+
+// writeLocks mutexes ordering writes that touch the same object
+type writeLocks struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// newWriteLocks write locks, nil when disabled
+func newWriteLocks(enabled bool) *writeLocks {
+	if !enabled {
+		return nil
+	}
+	return &writeLocks{locks: map[string]*sync.Mutex{}}
+}
+
+// lock acquire the mutex for key, returning the unlock function
+func (l *writeLocks) lock(key string) func() {
+	if l == nil {
+		return func() {}
+	}
+
+	l.Lock()
+	mu, ok := l.locks[key]
+	if !ok {
+		mu = &sync.Mutex{}
+		l.locks[key] = mu
+	}
+	l.Unlock()
+
+	mu.Lock()
+	return mu.Unlock
+}
+
+// hostLockKey lock key for writes to a host or template, and the items and applications on it
+func hostLockKey(hostid string) string {
+	return "host/" + hostid
+}
+
+// apiRetryLocked apiRetry while holding the write lock for key
+func apiRetryLocked(m interface{}, key string, call func() error) error {
+	unlock := m.(*providerMeta).WriteLocks.lock(key)
+	defer unlock()
+
+	return apiRetry(m, call)
+}
@@ -2,12 +2,8 @@ package provider
 
 import (
 	logger "log"
-	"os"
 )
 
-// this changes and no longer works if accessed later
-var stderr = os.Stderr
-
 type Log struct{}
 
 func (Log) Trace(msg string, args ...interface{}) {
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// number of slowest calls kept for the summary
+const API_STATS_SLOWEST = 5
+
+// apiMethodStats calls of one api method
+type apiMethodStats struct {
+	method string
+	calls  int
+	total  time.Duration
+	max    time.Duration
+}
+
+// apiCall a single timed call
+type apiCall struct {
+	method string
+	took   time.Duration
+}
+
+// apiMetrics api call counts and timings of this provider process
+type apiMetrics struct {
+	sync.Mutex
+	methods map[string]*apiMethodStats
+	calls   int
+	total   time.Duration
+	slowest []apiCall
+	logged  int
+}
+
+var apiStats = &apiMetrics{methods: map[string]*apiMethodStats{}}
+
+// record add a finished call
+func (s *apiMetrics) record(method string, took time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	m := s.methods[method]
+	if m == nil {
+		m = &apiMethodStats{method: method}
+		s.methods[method] = m
+	}
+	m.calls++
+	m.total += took
+	if took > m.max {
+		m.max = took
+	}
+
+	s.calls++
+	s.total += took
+
+	if len(s.slowest) < API_STATS_SLOWEST || took > s.slowest[len(s.slowest)-1].took {
+		s.slowest = append(s.slowest, apiCall{method: method, took: took})
+		sort.SliceStable(s.slowest, func(i, j int) bool {
+			return s.slowest[i].took > s.slowest[j].took
+		})
+		if len(s.slowest) > API_STATS_SLOWEST {
+			s.slowest = s.slowest[:API_STATS_SLOWEST]
+		}
+	}
+}
+
+// logSummary log call counts and time by method, most time first, and the slowest calls,
+// only if calls were made since the last summary
+func (s *apiMetrics) logSummary() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.calls == s.logged {
+		return
+	}
+	s.logged = s.calls
+
+	methods := make([]*apiMethodStats, 0, len(s.methods))
+	for _, m := range s.methods {
+		methods = append(methods, m)
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].total > methods[j].total
+	})
+
+	byMethod := make([]string, len(methods))
+	for i, m := range methods {
+		byMethod[i] = fmt.Sprintf("%s %d in %s (max %s)", m.method, m.calls, m.total, m.max)
+	}
+	slowest := make([]string, len(s.slowest))
+	for i, c := range s.slowest {
+		slowest[i] = fmt.Sprintf("%s %s", c.method, c.took)
+	}
+
+	log.Debug("zabbix api calls: %d in %s, by method: %s, slowest: %s", s.calls, s.total, strings.Join(byMethod, ", "), strings.Join(slowest, ", "))
+}
+
+// apiStatsWrap log the running api call summary after a resource function, while terraform still reads the log
+func apiStatsWrap(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		err := f(d, m)
+		apiStats.logSummary()
+		return err
+	}
+}
+
+// apiStatsResources log summaries after every create, read, update and delete of these resources
+func apiStatsResources(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		r.Create = apiStatsWrap(r.Create)
+		r.Read = apiStatsWrap(r.Read)
+		r.Update = apiStatsWrap(r.Update)
+		r.Delete = apiStatsWrap(r.Delete)
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// interval suffix multipliers, in seconds
+var INTERVAL_SUFFIXES = map[string]int{
+	"":  1,
+	"s": 1,
+	"m": 60,
+	"h": 3600,
+	"d": 86400,
+	"w": 604800,
+}
+
+var intervalRegexp = regexp.MustCompile("^([0-9]+)([smhdw]?)$")
+
+// intervalSeconds convert a simple interval (60, 60s, 1m, ...) to seconds
+func intervalSeconds(s string) (int, bool) {
+	match := intervalRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return n * INTERVAL_SUFFIXES[match[2]], true
+}
+
+// intervalDiffSuppress treat equivalent intervals as equal, flexible and scheduling intervals are compared per part
+func intervalDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	oldParts := strings.Split(old, ";")
+	newParts := strings.Split(new, ";")
+	if len(oldParts) != len(newParts) {
+		return false
+	}
+
+	for i := range oldParts {
+		o, oOk := intervalSeconds(oldParts[i])
+		n, nOk := intervalSeconds(newParts[i])
+
+		if oOk && nOk {
+			if o != n {
+				return false
+			}
+			continue
+		}
+		if strings.TrimSpace(oldParts[i]) != strings.TrimSpace(newParts[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// expressionWordRune part of a function, host, key or keyword token
+func expressionWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// expressionNormalize drop whitespace that does not separate two word tokens, quoted strings are left untouched
+func expressionNormalize(s string) string {
+	var b strings.Builder
+	quoted := false
+	escaped := false
+	pending := false
+	var last rune
+
+	for _, r := range strings.TrimSpace(s) {
+		if quoted {
+			b.WriteRune(r)
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				quoted = false
+			}
+			last = r
+			continue
+		}
+
+		if unicode.IsSpace(r) {
+			pending = true
+			continue
+		}
+		if pending && expressionWordRune(last) && expressionWordRune(r) {
+			b.WriteRune(' ')
+		}
+		pending = false
+
+		if r == '"' {
+			quoted = true
+		}
+		b.WriteRune(r)
+		last = r
+	}
+
+	return b.String()
+}
+
+// interfaceUseIPDiffSuppress useip has no effect on interfaces without an ip, the api reports them as dns
+func interfaceUseIPDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get(strings.TrimSuffix(k, "useip")+"ip").(string) == ""
+}
+
+// expressionDiffSuppress ignore whitespace only changes in expressions
+func expressionDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return expressionNormalize(old) == expressionNormalize(new)
+}
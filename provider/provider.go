@@ -1,7 +1,10 @@
 package provider
 
 import (
-	logger "log"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -11,26 +14,41 @@ import (
 
 // Provider definition
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"username": &schema.Schema{
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				Description:  "Zabbix API username",
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"ZABBIX_USER", "ZABBIX_USERNAME"}, nil),
 			},
 			"password": &schema.Schema{
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Sensitive:    true,
 				Description:  "Zabbix API password",
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"ZABBIX_PASS", "ZABBIX_PASSWORD"}, nil),
 			},
+			"api_token": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "Zabbix API token, used in place of username and password",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				DefaultFunc:  schema.EnvDefaultFunc("ZABBIX_API_TOKEN", nil),
+			},
+			"session_cache_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File to cache the login session in, reused between runs until it expires",
+				DefaultFunc: schema.EnvDefaultFunc("ZABBIX_SESSION_CACHE_FILE", nil),
+			},
 			"url": &schema.Schema{
 				Type:         schema.TypeString,
 				Required:     true,
-				Description:  "Zabbix API url",
+				Description:  "Zabbix API url, api_jsonrpc.php is appended unless the path ends in .php",
 				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"ZABBIX_URL", "ZABBIX_SERVER_URL"}, nil),
 				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
 			},
@@ -40,54 +58,317 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Default:     false,
 			},
+			"ca_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to a PEM CA bundle used to verify the API certificate",
+				DefaultFunc:   schema.EnvDefaultFunc("ZABBIX_CA_FILE", nil),
+				ConflictsWith: []string{"ca_pem"},
+			},
+			"ca_pem": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "PEM CA bundle used to verify the API certificate",
+				ConflictsWith: []string{"ca_file"},
+			},
+			"cert_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM client certificate, for mutual TLS",
+				DefaultFunc: schema.EnvDefaultFunc("ZABBIX_CERT_FILE", nil),
+			},
+			"key_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the PEM private key of cert_file",
+				DefaultFunc: schema.EnvDefaultFunc("ZABBIX_KEY_FILE", nil),
+			},
+			"proxy_url": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Outbound proxy for API requests (http, https or socks5), defaults to HTTPS_PROXY/HTTP_PROXY/NO_PROXY",
+				DefaultFunc:  schema.EnvDefaultFunc("ZABBIX_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				Description:  "API call timeout in seconds, including retries, 0 for no timeout",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Number of times to retry API reads failing with a connection error or 502/503/504, and calls failing with a database deadlock",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_backoff": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "Seconds to wait before the first retry, doubled on each further retry",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"minimum_version": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Minimum Zabbix server version, e.g. 5.0, fail early if the server is older",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9]+\.[0-9]+$`), "must be in the form major.minor"),
+			},
+			"http_headers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Extra HTTP headers added to every API request",
+			},
+			"default_tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags added to every host and trigger managed by the provider",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tag": tagSetSchema,
+					},
+				},
+			},
+			"validate_references": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Check referenced ids (hosts, groups, templates, ...) exist during plan",
+			},
+			"debug_api": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Log API requests and responses at debug level, with secrets redacted",
+				DefaultFunc: schema.EnvDefaultFunc("ZABBIX_DEBUG_API", false),
+			},
 			"serialize": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 				Description: "Serialize API requests, if required due to API race conditions",
+				Deprecated:  "use max_api_concurrency = 1 instead",
+			},
+			"max_api_concurrency": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum number of concurrent API requests, 0 for no limit",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"cache_reads": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Share identical host and item reads and name and reference lookups between resources, the cache is dropped on any write",
+			},
+			"lock_writes": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Order writes to the same host, template, or object type, unrelated writes still run in parallel",
+			},
+			"max_idle_conns": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				Description:  "Idle API connections kept open for reuse",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"item_batch_window": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Milliseconds to collect item creates on the same host into one API call, 0 to disable",
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"zabbix_host":        dataHost(),
-			"zabbix_proxy":       dataProxy(),
-			"zabbix_hostgroup":   dataHostgroup(),
-			"zabbix_template":    dataTemplate(),
-			"zabbix_application": dataApplication(),
+			"zabbix_api_version":        dataApiVersion(),
+			"zabbix_audit_log":          dataAuditLog(),
+			"zabbix_host":               dataHost(),
+			"zabbix_hosts":              dataHosts(),
+			"zabbix_host_interface":     dataHostInterface(),
+			"zabbix_proxy":              dataProxy(),
+			"zabbix_proxy_group":        dataProxyGroup(),
+			"zabbix_problems":           dataProblems(),
+			"zabbix_hostgroup":          dataHostgroup(),
+			"zabbix_hostgroups":         dataHostgroups(),
+			"zabbix_template":           dataTemplate(),
+			"zabbix_templates":          dataTemplates(),
+			"zabbix_template_export":    dataTemplateExport(),
+			"zabbix_application":        dataApplication(),
+			"zabbix_applications":       dataApplications(),
+			"zabbix_item":               dataItem(),
+			"zabbix_items":              dataItems(),
+			"zabbix_history":            dataHistory(),
+			"zabbix_lld_rule":           dataLLDRule(),
+			"zabbix_valuemap":           dataValuemap(),
+			"zabbix_service":            dataService(),
+			"zabbix_dashboard":          dataDashboard(),
+			"zabbix_trigger":            dataTrigger(),
+			"zabbix_trigger_expression": dataTriggerExpression(),
+			"zabbix_user":               dataUser(),
+			"zabbix_usergroup":          dataUsergroup(),
+			"zabbix_user_role":          dataUserRole(),
+			"zabbix_mediatype":          dataMediatype(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"zabbix_item_trapper":   resourceItemTrapper(),
-			"zabbix_item_http":      resourceItemHttp(),
-			"zabbix_item_simple":    resourceItemSimple(),
-			"zabbix_item_internal":  resourceItemInternal(),
-			"zabbix_item_snmp":      resourceItemSnmp(),
-			"zabbix_item_agent":     resourceItemAgent(),
-			"zabbix_item_aggregate": resourceItemAggregate(),
-			"zabbix_item_dependent": resourceItemDependent(),
-			"zabbix_application":    resourceApplication(),
-			"zabbix_trigger":        resourceTrigger(),
-			"zabbix_template":       resourceTemplate(),
-			"zabbix_hostgroup":      resourceHostgroup(),
-			"zabbix_host":           resourceHost(),
+			"zabbix_item_trapper":    resourceItemTrapper(),
+			"zabbix_item_http":       resourceItemHttp(),
+			"zabbix_item_simple":     resourceItemSimple(),
+			"zabbix_item_internal":   resourceItemInternal(),
+			"zabbix_item_snmp":       resourceItemSnmp(),
+			"zabbix_item_agent":      resourceItemAgent(),
+			"zabbix_item_aggregate":  resourceItemAggregate(),
+			"zabbix_item_dependent":  resourceItemDependent(),
+			"zabbix_application":     resourceApplication(),
+			"zabbix_trigger":         resourceTrigger(),
+			"zabbix_template":        resourceTemplate(),
+			"zabbix_template_import": resourceTemplateImport(),
+			"zabbix_hostgroup":       resourceHostgroup(),
+			"zabbix_host":            resourceHost(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
+
+	apiStatsResources(p.DataSourcesMap)
+	apiStatsResources(p.ResourcesMap)
+
+	return p
+}
+
+// providerMeta provider level state shared with all resources
+type providerMeta struct {
+	API          *zabbix.API
+	Version      serverVersion
+	DefaultTags  zabbix.Tags
+	Retries      int
+	RetryBackoff time.Duration
+	ItemBatcher  *itemBatcher
+	WriteLocks   *writeLocks
+	HostIDs      *nameCache
+
+	ValidateReferences bool
 }
 
 // providerConfigure configure this provider
 func providerConfigure(d *schema.ResourceData) (meta interface{}, err error) {
 	log.Trace("Started zabbix provider init")
-	l := logger.New(stderr, "[DEBUG] ", logger.LstdFlags)
+
+	endpoint, err := apiURL(d.Get("url").(string))
+	if err != nil {
+		return
+	}
 
 	api := zabbix.NewAPI(zabbix.Config{
-		Url:         d.Get("url").(string),
+		Url:         endpoint,
 		TlsNoVerify: d.Get("tls_insecure").(bool),
-		Log:         l,
 		Serialize:   d.Get("serialize").(bool),
 	})
 
-	_, err = api.Login(d.Get("username").(string), d.Get("password").(string))
-	meta = api
+	client, transport, err := buildClient(d)
+	if err != nil {
+		return
+	}
+	api.SetClient(client)
+
+	// the version call needs no login, failing here means the url is wrong or unreachable
+	version, err := apiVersion(api)
+	if err != nil {
+		err = fmt.Errorf("unable to reach the zabbix api at %s: %s", endpoint, err)
+		return
+	}
+
+	if min := d.Get("minimum_version").(string); min != "" {
+		var minVersion serverVersion
+		if minVersion, err = parseServerVersion(min); err != nil {
+			return
+		}
+		if !version.AtLeast(minVersion.Major, minVersion.Minor) {
+			err = fmt.Errorf("zabbix server version %s is older than minimum_version %s", version, minVersion)
+			return
+		}
+	}
+
+	err = providerLogin(d, api, version, transport)
+	if err != nil {
+		return
+	}
+
+	if d.Get("cache_reads").(bool) {
+		readCacheEnable(api)
+	}
+
+	defaultTags := zabbix.Tags{}
+	if v, ok := d.GetOk("default_tags.0.tag"); ok {
+		defaultTags = tagGenerateSet(v.(*schema.Set))
+	}
+
+	meta = &providerMeta{
+		API:          api,
+		Version:      version,
+		DefaultTags:  defaultTags,
+		Retries:      d.Get("retries").(int),
+		RetryBackoff: time.Duration(d.Get("retry_backoff").(int)) * time.Second,
+		WriteLocks:   newWriteLocks(d.Get("lock_writes").(bool)),
+		ItemBatcher:  newItemBatcher(time.Duration(d.Get("item_batch_window").(int)) * time.Millisecond),
+		HostIDs:      newNameCache(),
+
+		ValidateReferences: d.Get("validate_references").(bool),
+	}
 	log.Trace("Started zabbix provider got error: %+v", err)
 
 	return
 }
+
+// providerLogin authenticate against the api, with a token or username and password
+func providerLogin(d *schema.ResourceData, api *zabbix.API, version serverVersion, transport *apiTransport) error {
+	if token := d.Get("api_token").(string); token != "" {
+		// 6.4 moved auth into a header, the auth request property is removed in 7.2
+		if version.AtLeast(6, 4) {
+			if _, ok := transport.headers["Authorization"]; ok {
+				return errors.New("api_token can not be used with an Authorization entry in http_headers on zabbix 6.4 or later")
+			}
+			transport.headers["Authorization"] = "Bearer " + token
+		} else if version.AtLeast(5, 4) {
+			api.Auth = token
+		} else {
+			return fmt.Errorf("api_token requires zabbix 5.4 or later, server is %s", version)
+		}
+		return nil
+	}
+
+	username := d.Get("username").(string)
+	password := d.Get("password").(string)
+	if username == "" || password == "" {
+		return errors.New("either api_token or both username and password must be provided")
+	}
+
+	url := d.Get("url").(string)
+	file := d.Get("session_cache_file").(string)
+
+	if file != "" {
+		if session := sessionCacheLoad(file, url, username); session != "" && sessionValid(api, session) {
+			log.Debug("reusing cached session from %s", file)
+			api.Auth = session
+			return nil
+		}
+	}
+
+	session, err := sessionLogin(api, version, username, password)
+	if err != nil {
+		return err
+	}
+
+	if file != "" {
+		sessionCacheSave(file, url, username, session)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// purge attributes shared by hosts and templates
+var purgeSchema = map[string]*schema.Schema{
+	"purge_unmanaged": &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Delete items and triggers without the provider default tags, zabbix 5.4+",
+	},
+	"unmanaged_items": &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "IDs of items missing the provider default tags, only read with purge_unmanaged",
+	},
+	"unmanaged_triggers": &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "IDs of triggers missing the provider default tags, only read with purge_unmanaged",
+	},
+}
+
+// purgeManaged check an object carries every provider default tag
+func purgeManaged(tags zabbix.Tags, defaults zabbix.Tags) bool {
+	have := map[zabbix.Tag]bool{}
+	for _, t := range tags {
+		have[t] = true
+	}
+	for _, t := range defaults {
+		if !have[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// purgeUnmanaged ids of objects of a get method on a host missing the default tags
+// inherited and discovered objects are left alone, they go with their template or rule
+func purgeUnmanaged(meta *providerMeta, method, idField, hostid string) ([]interface{}, error) {
+	var raw []map[string]interface{}
+
+	err := apiGet(meta.API, method, zabbix.Params{
+		"output":     []string{idField, "flags"},
+		"hostids":    []string{hostid},
+		"inherited":  false,
+		"selectTags": "extend",
+	}, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []interface{}{}
+	for _, v := range raw {
+		if flags, _ := v["flags"].(string); flags == FLAGS_DISCOVERED {
+			continue
+		}
+
+		tags := zabbix.Tags{}
+		list, _ := v["tags"].([]interface{})
+		for _, t := range list {
+			tag, _ := t.(map[string]interface{})
+			key, _ := tag["tag"].(string)
+			value, _ := tag["value"].(string)
+			tags = append(tags, zabbix.Tag{Tag: key, Value: value})
+		}
+
+		if !purgeManaged(tags, meta.DefaultTags) {
+			ids = append(ids, v[idField])
+		}
+	}
+
+	return ids, nil
+}
+
+// purgeRead record unmanaged items and triggers of a host or template
+func purgeRead(d *schema.ResourceData, m interface{}) error {
+	if !d.Get("purge_unmanaged").(bool) {
+		d.Set("unmanaged_items", schema.NewSet(schema.HashString, []interface{}{}))
+		d.Set("unmanaged_triggers", schema.NewSet(schema.HashString, []interface{}{}))
+		return nil
+	}
+	meta := m.(*providerMeta)
+
+	items, err := purgeUnmanaged(meta, "item.get", "itemid", d.Id())
+	if err != nil {
+		return err
+	}
+	triggers, err := purgeUnmanaged(meta, "trigger.get", "triggerid", d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.Set("unmanaged_items", schema.NewSet(schema.HashString, items))
+	d.Set("unmanaged_triggers", schema.NewSet(schema.HashString, triggers))
+
+	return nil
+}
+
+// purgeCustomizeDiff plan the removal of unmanaged objects, forcing an update
+func purgeCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("purge_unmanaged").(bool) {
+		return nil
+	}
+	meta := m.(*providerMeta)
+
+	// without a marker every object would look unmanaged
+	if len(meta.DefaultTags) < 1 {
+		return errors.New("purge_unmanaged requires provider default_tags, they mark managed items and triggers")
+	}
+	if !meta.Version.AtLeast(5, 4) {
+		return errors.New("purge_unmanaged requires zabbix 5.4 or later for item tags")
+	}
+
+	for _, k := range []string{"unmanaged_items", "unmanaged_triggers"} {
+		if v, ok := d.Get(k).(*schema.Set); ok && v.Len() > 0 {
+			if err := d.SetNew(k, schema.NewSet(schema.HashString, []interface{}{})); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// purgeApply delete the unmanaged objects found by the last read
+func purgeApply(d *schema.ResourceData, m interface{}) error {
+	if !d.Get("purge_unmanaged").(bool) {
+		return nil
+	}
+	api := m.(*providerMeta).API
+
+	// triggers first, deleting an item also removes its triggers
+	triggers, _ := d.GetChange("unmanaged_triggers")
+	if ids := setToStrings(triggers.(*schema.Set)); len(ids) > 0 {
+		log.Info("purging unmanaged triggers %v of %s", ids, d.Id())
+		err := apiRetryLocked(m, "trigger", func() error {
+			return api.TriggersDeleteByIds(ids)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	items, _ := d.GetChange("unmanaged_items")
+	if ids := setToStrings(items.(*schema.Set)); len(ids) > 0 {
+		log.Info("purging unmanaged items %v of %s", ids, d.Id())
+		err := apiRetryLocked(m, hostLockKey(d.Id()), func() error {
+			return api.ItemsDeleteByIds(ids)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setToStrings list the members of a string set
+func setToStrings(s *schema.Set) []string {
+	list := s.List()
+	out := make([]string, len(list))
+	for i := range list {
+		out[i] = list[i].(string)
+	}
+	return out
+}
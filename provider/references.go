@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// referenceType how to look up a referenced object type
+type referenceType struct {
+	Method string
+	Param  string
+	Field  string
+	Extra  zabbix.Params
+}
+
+// lookups for referenced object types
+var REFERENCE_TYPES = map[string]referenceType{
+	"host":        {"host.get", "hostids", "hostid", zabbix.Params{"templated_hosts": true}},
+	"hostgroup":   {"hostgroup.get", "groupids", "groupid", nil},
+	"template":    {"template.get", "templateids", "templateid", nil},
+	"application": {"application.get", "applicationids", "applicationid", nil},
+	"item":        {"item.get", "itemids", "itemid", nil},
+	"trigger":     {"trigger.get", "triggerids", "triggerid", nil},
+}
+
+// referenceCheck CustomizeDiff verifying referenced ids exist, if enabled on the provider
+// checks maps attribute names to referenceType keys, string and set attributes are supported
+func referenceCheck(checks map[string]string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+		meta := m.(*providerMeta)
+		if !meta.ValidateReferences {
+			return nil
+		}
+
+		for attr, kind := range checks {
+			// unknown values reference objects created in this apply
+			if !d.HasChange(attr) || !d.NewValueKnown(attr) {
+				continue
+			}
+
+			ids := []string{}
+			switch v := d.Get(attr).(type) {
+			case string:
+				if v != "" && v != "0" {
+					ids = append(ids, v)
+				}
+			case *schema.Set:
+				for _, id := range v.List() {
+					ids = append(ids, id.(string))
+				}
+			}
+			if len(ids) < 1 {
+				continue
+			}
+
+			if err := referenceExists(meta, kind, ids); err != nil {
+				return fmt.Errorf("%s: %s", attr, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// referenceExists check all ids of a type exist, naming the first missing one
+func referenceExists(meta *providerMeta, kind string, ids []string) error {
+	ref := REFERENCE_TYPES[kind]
+
+	if kind == "application" {
+		if err := applicationSupported(meta); err != nil {
+			return err
+		}
+	}
+
+	// template groups were split from host groups in 6.2
+	if kind == "templategroup" {
+		ref = REFERENCE_TYPES["hostgroup"]
+		if meta.Version.AtLeast(6, 2) {
+			ref.Method = "templategroup.get"
+		}
+	}
+
+	params := zabbix.Params{
+		"output":  []string{ref.Field},
+		ref.Param: ids,
+	}
+	for k, v := range ref.Extra {
+		params[k] = v
+	}
+
+	var found []map[string]interface{}
+	if err := apiGet(meta.API, ref.Method, params, &found); err != nil {
+		return err
+	}
+
+	exists := map[string]bool{}
+	for _, v := range found {
+		if id, ok := v[ref.Field].(string); ok {
+			exists[id] = true
+		}
+	}
+
+	for _, id := range ids {
+		if !exists[id] {
+			return fmt.Errorf("%s %s does not exist", kind, id)
+		}
+	}
+
+	return nil
+}
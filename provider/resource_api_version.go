@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// dataApiVersion terraform data source exposing the server api version
+func dataApiVersion() *schema.Resource {
+	return &schema.Resource{
+		Read: dataApiVersionRead,
+
+		Schema: map[string]*schema.Schema{
+			"version": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full API version, e.g. 6.0.12",
+			},
+			"major": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Major version",
+			},
+			"minor": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Minor version",
+			},
+		},
+	}
+}
+
+// dataApiVersionRead read handler for data resource
+func dataApiVersionRead(d *schema.ResourceData, m interface{}) error {
+	meta := m.(*providerMeta)
+
+	str, err := meta.API.Version()
+	if err != nil {
+		return err
+	}
+
+	d.SetId(str)
+	d.Set("version", str)
+	d.Set("major", meta.Version.Major)
+	d.Set("minor", meta.Version.Minor)
+
+	return nil
+}
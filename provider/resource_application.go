@@ -2,6 +2,7 @@ package provider
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -27,6 +28,14 @@ var applicationSchemaBase = map[string]*schema.Schema{
 	},
 }
 
+// applicationSupported applications were replaced by item tags in zabbix 5.4
+func applicationSupported(meta *providerMeta) error {
+	if meta.Version.AtLeast(5, 4) {
+		return fmt.Errorf("applications were removed in zabbix 5.4, server is %s, use item tag blocks instead", meta.Version)
+	}
+	return nil
+}
+
 // resourceApplication terraform application resource entrypoint
 func resourceApplication() *schema.Resource {
 	return &schema.Resource{
@@ -34,10 +43,11 @@ func resourceApplication() *schema.Resource {
 		Read:   resourceApplicationRead,
 		Update: resourceApplicationUpdate,
 		Delete: resourceApplicationDelete,
-		Schema: applicationResourceSchema(applicationSchemaBase),
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		CustomizeDiff: referenceCheck(map[string]string{
+			"hostid": "host",
+		}),
+		Schema:   applicationResourceSchema(applicationSchemaBase),
+		Importer: importByName("application.get", "applicationid", []string{"hostid", "name"}),
 	}
 }
 
@@ -78,9 +88,17 @@ func applicationDataSchema(m map[string]*schema.Schema) (o map[string]*schema.Sc
 		// case "applicationid", "flags", "templateids":
 		// 	schema.Optional = true
 		// }
+		if k == "name" {
+			schema.Required = false
+			schema.Optional = true
+			schema.Computed = true
+		}
 
 		o[k] = &schema
 	}
+	for k, v := range searchSchema {
+		o[k] = v
+	}
 
 	// lookup vars
 	// o["hostid"] = &schema.Schema{
@@ -105,7 +123,11 @@ func buildApplicationObject(d *schema.ResourceData) (*zabbix.Application, error)
 
 // resourceApplicationCreate terraform create handler
 func resourceApplicationCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
+
+	if err := applicationSupported(m.(*providerMeta)); err != nil {
+		return err
+	}
 
 	item, err := buildApplicationObject(d)
 	if err != nil {
@@ -114,7 +136,9 @@ func resourceApplicationCreate(d *schema.ResourceData, m interface{}) error {
 
 	items := []zabbix.Application{*item}
 
-	err = api.ApplicationsCreate(items)
+	err = apiRetryLocked(m, hostLockKey(item.HostID), func() error {
+		return api.ApplicationsCreate(items)
+	})
 	if err != nil {
 		return err
 	}
@@ -128,6 +152,10 @@ func resourceApplicationCreate(d *schema.ResourceData, m interface{}) error {
 
 // dataApplicationRead read handler for data resource
 func dataApplicationRead(d *schema.ResourceData, m interface{}) error {
+	if err := applicationSupported(m.(*providerMeta)); err != nil {
+		return err
+	}
+
 	params := zabbix.Params{
 		"filter": map[string]interface{}{},
 	}
@@ -139,6 +167,11 @@ func dataApplicationRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
+	if searchEmpty(d, params) {
+		return errors.New("no application lookup attribute")
+	}
+	searchParams(d, params, "applicationid")
+
 	log.Debug("performing data lookup with params: %#v", params)
 
 	return applicationRead(d, m, params)
@@ -146,6 +179,13 @@ func dataApplicationRead(d *schema.ResourceData, m interface{}) error {
 
 // resourceApplicationRead read handler for resource
 func resourceApplicationRead(d *schema.ResourceData, m interface{}) error {
+	// the server was upgraded past applications, nothing is left to manage
+	if err := applicationSupported(m.(*providerMeta)); err != nil {
+		log.Warn("removing application %s from state: %s", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
 	log.Debug("Lookup of ??? with id %s", d.Id()) // TBD
 
 	return applicationRead(d, m, zabbix.Params{
@@ -155,7 +195,7 @@ func resourceApplicationRead(d *schema.ResourceData, m interface{}) error {
 
 // applicationRead common application read function
 func applicationRead(d *schema.ResourceData, m interface{}, params zabbix.Params) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
 
 	log.Debug("Lookup of application with params %#v", params)
 
@@ -190,32 +230,47 @@ func applicationRead(d *schema.ResourceData, m interface{}, params zabbix.Params
 	return nil
 }
 
-// resourceApplicationUpdate terraform update resource handler
+// resourceApplicationUpdate terraform update resource handler, only the name can change
 func resourceApplicationUpdate(d *schema.ResourceData, m interface{}) error {
-	return errors.New("Unimplemented error")
-	// api := m.(*zabbix.API)
+	meta := m.(*providerMeta)
+	api := meta.API
 
-	// item, err := buildApplicationObject(d)
-
-	// if err != nil {
-	// 	return err
-	// }
-
-	// item.ApplicationID = d.Id()
+	if err := applicationSupported(meta); err != nil {
+		return err
+	}
+	if err := objectEditable(api, "application", d.Id()); err != nil {
+		return err
+	}
 
-	// items := []zabbix.Application{*item}
+	// hostid is fixed after create, send only what may change
+	params := zabbix.Params{
+		"applicationid": d.Id(),
+		"name":          d.Get("name").(string),
+	}
 
-	// err = api.ApplicationsUpdate(items)
+	err := apiRetryLocked(m, hostLockKey(d.Get("hostid").(string)), func() error {
+		_, err := api.CallWithError("application.update", params)
+		return err
+	})
 
-	// if err != nil {
-	// 	return err
-	// }
+	if err != nil {
+		return err
+	}
 
-	// return resourceApplicationRead(d, m)
+	return resourceApplicationRead(d, m)
 }
 
 // resourceApplicationDelete terraform delete resource handler
 func resourceApplicationDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
-	return api.ApplicationsDeleteByIds([]string{d.Id()})
+	api := m.(*providerMeta).API
+
+	if applicationSupported(m.(*providerMeta)) != nil {
+		return nil
+	}
+	if err := objectEditable(api, "application", d.Id()); err != nil {
+		return err
+	}
+	return apiRetryLocked(m, hostLockKey(d.Get("hostid").(string)), func() error {
+		return api.ApplicationsDeleteByIds([]string{d.Id()})
+	})
 }
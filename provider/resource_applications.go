@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform/helper/hashcode"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// dataApplications terraform data source returning all applications on a host
+func dataApplications() *schema.Resource {
+	return &schema.Resource{
+		Read: dataApplicationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"hostid": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Host ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Only return applications with a name matching, * is a wildcard",
+			},
+			"applications": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"applicationid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Matching applications",
+			},
+		},
+	}
+}
+
+// dataApplicationsRead read handler for data resource
+func dataApplicationsRead(d *schema.ResourceData, m interface{}) error {
+	meta := m.(*providerMeta)
+
+	if err := applicationSupported(meta); err != nil {
+		return err
+	}
+
+	params := zabbix.Params{
+		"hostids":   d.Get("hostid"),
+		"sortfield": "name",
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		params["search"] = map[string]interface{}{
+			"name": v,
+		}
+		params["searchWildcardsEnabled"] = true
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	apps, err := meta.API.ApplicationsGet(params)
+	if err != nil {
+		return err
+	}
+
+	list := make([]interface{}, len(apps))
+	for i, app := range apps {
+		list[i] = map[string]interface{}{
+			"applicationid": app.ApplicationID,
+			"name":          app.Name,
+		}
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%v", params))))
+	d.Set("applications", list)
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform/helper/hashcode"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// auditLogObject audit log record, not modelled by the library
+type auditLogObject struct {
+	AuditID      string `json:"auditid"`
+	UserID       string `json:"userid"`
+	Username     string `json:"username,omitempty"`
+	Clock        string `json:"clock"`
+	IP           string `json:"ip"`
+	Action       string `json:"action"`
+	ResourceType string `json:"resourcetype"`
+	ResourceID   string `json:"resourceid"`
+	ResourceName string `json:"resourcename"`
+	Details      string `json:"details,omitempty"`
+}
+
+// dataAuditLog terraform data source returning audit log records
+func dataAuditLog() *schema.Resource {
+	return &schema.Resource{
+		Read: dataAuditLogRead,
+
+		Schema: map[string]*schema.Schema{
+			"time_from": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Only return records at or after this unix time",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"time_till": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Only return records at or before this unix time",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"userids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Description: "Only return records of these users",
+			},
+			"resource_types": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				Description: "Only return records of these zabbix resource type numbers, e.g. 4 for hosts",
+			},
+			"limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				Description:  "Maximum number of records to return",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"records": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auditid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"userid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"username": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"clock": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ip": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"resource_type": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"resourceid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"details": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Audit log records, newest first",
+			},
+		},
+	}
+}
+
+// dataAuditLogRead read handler for data resource
+func dataAuditLogRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"output":    "extend",
+		"sortfield": "clock",
+		"sortorder": "DESC",
+		"limit":     d.Get("limit").(int),
+		"filter":    map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("time_from"); ok {
+		params["time_from"] = v
+	}
+	if v, ok := d.GetOk("time_till"); ok {
+		params["time_till"] = v
+	}
+	if v := d.Get("userids").(*schema.Set); v.Len() > 0 {
+		params["filter"].(map[string]interface{})["userid"] = v.List()
+	}
+	if v := d.Get("resource_types").(*schema.Set); v.Len() > 0 {
+		params["filter"].(map[string]interface{})["resourcetype"] = v.List()
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var records []auditLogObject
+	if err := api.CallWithErrorParse("auditlog.get", params, &records); err != nil {
+		return err
+	}
+
+	list := make([]interface{}, len(records))
+	for i, r := range records {
+		clock, _ := strconv.ParseInt(r.Clock, 10, 64)
+		action, _ := strconv.Atoi(r.Action)
+		resourceType, _ := strconv.Atoi(r.ResourceType)
+		list[i] = map[string]interface{}{
+			"auditid":       r.AuditID,
+			"userid":        r.UserID,
+			"username":      r.Username,
+			"clock":         clock,
+			"ip":            r.IP,
+			"action":        action,
+			"resource_type": resourceType,
+			"resourceid":    r.ResourceID,
+			"resource_name": r.ResourceName,
+			"details":       r.Details,
+		}
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%v", params))))
+	d.Set("records", list)
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// dashboardObject zabbix dashboard, not modelled by the library
+type dashboardObject struct {
+	DashboardID string                `json:"dashboardid,omitempty"`
+	Name        string                `json:"name"`
+	Pages       []dashboardPageObject `json:"pages,omitempty"`
+}
+
+// dashboardPageObject dashboard page, zabbix 5.4+
+type dashboardPageObject struct {
+	DashboardPageID string `json:"dashboard_pageid,omitempty"`
+	Name            string `json:"name"`
+}
+
+// dataDashboard terraform dashboard data source entrypoint
+func dataDashboard() *schema.Resource {
+	return &schema.Resource{
+		Read: dataDashboardRead,
+
+		Schema: map[string]*schema.Schema{
+			"dashboardid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Dashboard ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Dashboard name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"page": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Dashboard pages, zabbix 5.4+",
+			},
+		},
+	}
+}
+
+// dataDashboardRead read handler for data resource
+func dataDashboardRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"output": []string{"dashboardid", "name"},
+		"filter": map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("dashboardid"); ok {
+		params["dashboardids"] = v
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params["filter"].(map[string]interface{})["name"] = v
+	}
+
+	if params["dashboardids"] == nil && len(params["filter"].(map[string]interface{})) < 1 {
+		return errors.New("no dashboard lookup attribute")
+	}
+
+	version, err := apiVersion(api)
+	if err != nil {
+		return err
+	}
+	if version.AtLeast(5, 4) {
+		params["selectPages"] = []string{"dashboard_pageid", "name"}
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var dashboards []dashboardObject
+	if err := api.CallWithErrorParse("dashboard.get", params, &dashboards); err != nil {
+		return err
+	}
+
+	if len(dashboards) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(dashboards) > 1 {
+		return errors.New("multiple dashboards found")
+	}
+	dashboard := dashboards[0]
+
+	log.Debug("Got dashboard: %+v", dashboard)
+
+	pages := make([]interface{}, len(dashboard.Pages))
+	for i, p := range dashboard.Pages {
+		pages[i] = map[string]interface{}{
+			"id":   p.DashboardPageID,
+			"name": p.Name,
+		}
+	}
+
+	d.SetId(dashboard.DashboardID)
+	d.Set("dashboardid", dashboard.DashboardID)
+	d.Set("name", dashboard.Name)
+	d.Set("page", pages)
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// historyObject history or trend record, not modelled by the library
+type historyObject struct {
+	Clock    string `json:"clock"`
+	Value    string `json:"value,omitempty"`
+	ValueMin string `json:"value_min,omitempty"`
+	ValueAvg string `json:"value_avg,omitempty"`
+	ValueMax string `json:"value_max,omitempty"`
+}
+
+// dataHistory terraform data source returning recent item values
+func dataHistory() *schema.Resource {
+	return &schema.Resource{
+		Read: dataHistoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"itemid": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Item ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"period": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3600,
+				Description:  "Seconds of history to return, counting back from now",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				Description:  "Maximum number of values to return",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"trends": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Return hourly trends instead of history, numeric items only",
+			},
+			"values": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"clock": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"min": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"max": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Values, newest first",
+			},
+		},
+	}
+}
+
+// dataHistoryRead read handler for data resource
+func dataHistoryRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	itemid := d.Get("itemid").(string)
+
+	// history is stored per value type, which must be passed in
+	items, err := api.ItemsGet(zabbix.Params{
+		"itemids": []string{itemid},
+		"output":  []string{"itemid", "value_type"},
+	})
+	if err != nil {
+		return err
+	}
+	if len(items) != 1 {
+		return fmt.Errorf("expected one item with id %s, found %d", itemid, len(items))
+	}
+	valueType := items[0].ValueType
+
+	params := zabbix.Params{
+		"itemids":   []string{itemid},
+		"time_from": time.Now().Unix() - int64(d.Get("period").(int)),
+		"sortfield": "clock",
+		"sortorder": "DESC",
+		"limit":     d.Get("limit").(int),
+	}
+
+	method := "history.get"
+	if d.Get("trends").(bool) {
+		if valueType != zabbix.Float && valueType != zabbix.Unsigned {
+			return errors.New("trends are only kept for numeric items")
+		}
+		method = "trend.get"
+		params["output"] = []string{"clock", "value_min", "value_avg", "value_max"}
+	} else {
+		params["history"] = valueType
+		params["output"] = "extend"
+	}
+
+	log.Debug("performing %s lookup with params: %#v", method, params)
+
+	var records []historyObject
+	if err := api.CallWithErrorParse(method, params, &records); err != nil {
+		return err
+	}
+
+	list := make([]interface{}, len(records))
+	for i, r := range records {
+		v := map[string]interface{}{
+			"clock": r.Clock,
+			"value": r.Value,
+		}
+		if method == "trend.get" {
+			v["value"] = r.ValueAvg
+			v["min"] = r.ValueMin
+			v["max"] = r.ValueMax
+		}
+		list[i] = v
+	}
+
+	d.SetId(itemid)
+	d.Set("values", list)
+
+	return nil
+}
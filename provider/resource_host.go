@@ -1,11 +1,14 @@
 package provider
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 
@@ -32,6 +35,269 @@ var HOST_IFACE_PORTS = map[string]int{
 	"jmx":   8686,
 }
 
+// inventory mode conversions
+var HOST_INVENTORY_MODES = map[string]string{
+	"disabled":  "-1",
+	"manual":    "0",
+	"automatic": "1",
+}
+var HOST_INVENTORY_MODES_REV = map[string]string{}
+var HOST_INVENTORY_MODES_ARR = []string{}
+
+// ipmi conversions
+var HOST_IPMI_AUTHTYPES = map[string]string{
+	"default":  "-1",
+	"none":     "0",
+	"md2":      "1",
+	"md5":      "2",
+	"straight": "4",
+	"oem":      "5",
+	"rmcp+":    "6",
+}
+var HOST_IPMI_AUTHTYPES_REV = map[string]string{}
+var HOST_IPMI_AUTHTYPES_ARR = []string{}
+
+var HOST_IPMI_PRIVILEGES = map[string]string{
+	"callback": "1",
+	"user":     "2",
+	"operator": "3",
+	"admin":    "4",
+	"oem":      "5",
+}
+var HOST_IPMI_PRIVILEGES_REV = map[string]string{}
+var HOST_IPMI_PRIVILEGES_ARR = []string{}
+
+// monitored_by conversions (zabbix 7.0+)
+var HOST_MONITORED_BY = map[string]string{
+	"server":      "0",
+	"proxy":       "1",
+	"proxy_group": "2",
+}
+var HOST_MONITORED_BY_ARR = []string{"server", "proxy", "proxy_group"}
+
+// tls conversions, accept is a bitmask of these values
+var HOST_TLS_TYPES = map[string]int{
+	"unencrypted": 1,
+	"psk":         2,
+	"cert":        4,
+}
+var HOST_TLS_TYPES_REV = map[int]string{}
+var HOST_TLS_TYPES_ARR = []string{}
+
+// generate the above structures
+var _ = func() bool {
+	for k, v := range HOST_TLS_TYPES {
+		HOST_TLS_TYPES_REV[v] = k
+		HOST_TLS_TYPES_ARR = append(HOST_TLS_TYPES_ARR, k)
+	}
+	for k, v := range HOST_INVENTORY_MODES {
+		HOST_INVENTORY_MODES_REV[v] = k
+		HOST_INVENTORY_MODES_ARR = append(HOST_INVENTORY_MODES_ARR, k)
+	}
+	for k, v := range HOST_IPMI_AUTHTYPES {
+		HOST_IPMI_AUTHTYPES_REV[v] = k
+		HOST_IPMI_AUTHTYPES_ARR = append(HOST_IPMI_AUTHTYPES_ARR, k)
+	}
+	for k, v := range HOST_IPMI_PRIVILEGES {
+		HOST_IPMI_PRIVILEGES_REV[v] = k
+		HOST_IPMI_PRIVILEGES_ARR = append(HOST_IPMI_PRIVILEGES_ARR, k)
+	}
+	return false
+}()
+
+// host inventory fields, as named by the zabbix api
+var HOST_INVENTORY_FIELDS = []string{
+	"type", "type_full", "name", "alias", "os", "os_full", "os_short",
+	"serialno_a", "serialno_b", "tag", "asset_tag", "macaddress_a", "macaddress_b",
+	"hardware", "hardware_full", "software", "software_full",
+	"software_app_a", "software_app_b", "software_app_c", "software_app_d", "software_app_e",
+	"contact", "location", "location_lat", "location_lon", "notes",
+	"chassis", "model", "hw_arch", "vendor", "contract_number", "installer_name", "deployment_status",
+	"url_a", "url_b", "url_c",
+	"host_networks", "host_netmask", "host_router", "oob_ip", "oob_netmask", "oob_router",
+	"date_hw_purchase", "date_hw_install", "date_hw_expiry", "date_hw_decomm",
+	"site_address_a", "site_address_b", "site_address_c", "site_city", "site_state",
+	"site_country", "site_zip", "site_rack", "site_notes",
+	"poc_1_name", "poc_1_email", "poc_1_phone_a", "poc_1_phone_b", "poc_1_cell", "poc_1_screen", "poc_1_notes",
+	"poc_2_name", "poc_2_email", "poc_2_phone_a", "poc_2_phone_b", "poc_2_cell", "poc_2_screen", "poc_2_notes",
+}
+
+// hostInventory inventory key/value pairs
+type hostInventory map[string]string
+
+// UnmarshalJSON the api returns an empty array instead of an object when inventory is disabled
+func (i *hostInventory) UnmarshalJSON(b []byte) error {
+	if string(b) == "[]" {
+		*i = hostInventory{}
+		return nil
+	}
+
+	m := map[string]string{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	*i = m
+
+	return nil
+}
+
+// hostInterfaceDetails snmp interface details (zabbix 5.0+)
+type hostInterfaceDetails struct {
+	Version        string `json:"version,omitempty"`
+	Bulk           string `json:"bulk,omitempty"`
+	Community      string `json:"community,omitempty"`
+	SecurityName   string `json:"securityname,omitempty"`
+	SecurityLevel  string `json:"securitylevel,omitempty"`
+	AuthPassphrase string `json:"authpassphrase,omitempty"`
+	PrivPassphrase string `json:"privpassphrase,omitempty"`
+	AuthProtocol   string `json:"authprotocol,omitempty"`
+	PrivProtocol   string `json:"privprotocol,omitempty"`
+	ContextName    string `json:"contextname,omitempty"`
+}
+
+// UnmarshalJSON the api returns an empty array instead of an object for non snmp interfaces
+func (i *hostInterfaceDetails) UnmarshalJSON(b []byte) error {
+	if string(b) == "[]" {
+		return nil
+	}
+
+	type plain hostInterfaceDetails
+	return json.Unmarshal(b, (*plain)(i))
+}
+
+// hostInterface library interface struct, extended with snmp details
+type hostInterface struct {
+	zabbix.HostInterface
+	Details *hostInterfaceDetails `json:"details,omitempty"`
+}
+type hostInterfaces []hostInterface
+
+// hostObject library host struct, extended with attributes it does not model
+type hostObject struct {
+	zabbix.Host
+	Interfaces     hostInterfaces `json:"interfaces,omitempty"`
+	UserMacros     macroObjects   `json:"macros,omitempty"`
+	Description    string         `json:"description"`
+	InventoryMode  string         `json:"inventory_mode,omitempty"`
+	Inventory      hostInventory  `json:"inventory,omitempty"`
+	IPMIAuthType   string         `json:"ipmi_authtype,omitempty"`
+	IPMIPrivilege  string         `json:"ipmi_privilege,omitempty"`
+	IPMIUsername   string         `json:"ipmi_username"`
+	IPMIPassword   string         `json:"ipmi_password"`
+	TLSConnect     int            `json:"tls_connect,string,omitempty"`
+	TLSAccept      int            `json:"tls_accept,string,omitempty"`
+	TLSPSKIdentity *string        `json:"tls_psk_identity,omitempty"`
+	TLSPSK         *string        `json:"tls_psk,omitempty"`
+	TLSIssuer      string         `json:"tls_issuer"`
+	TLSSubject     string         `json:"tls_subject"`
+	Tags           *zabbix.Tags   `json:"tags,omitempty"`
+
+	TemplateIDsClear zabbix.TemplateIDs `json:"templates_clear,omitempty"`
+
+	// zabbix 7.0 replaces proxy_hostid
+	MonitoredBy      string `json:"monitored_by,omitempty"`
+	MonitoredProxyID string `json:"proxyid,omitempty"`
+	ProxyGroupID     string `json:"proxy_groupid,omitempty"`
+}
+
+// hostInventorySchema inventory block schema, one optional attribute per inventory field
+func hostInventorySchema() *schema.Schema {
+	fields := map[string]*schema.Schema{}
+
+	for _, k := range HOST_INVENTORY_FIELDS {
+		fields[k] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Inventory field " + k,
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Host inventory",
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+// hostInterfaceDetailsSchema snmp interface details block
+var hostInterfaceDetailsSchema = &schema.Schema{
+	Type:        schema.TypeList,
+	Optional:    true,
+	Computed:    true,
+	MaxItems:    1,
+	Description: "SNMP interface details (zabbix 5.0+)",
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"version": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "2",
+				Description:  "SNMP Version, one of: 1, 2, 3",
+				ValidateFunc: validation.StringInSlice([]string{"1", "2", "3"}, false),
+			},
+			"bulk": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Use bulk requests",
+			},
+			"community": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Default:     "{$SNMP_COMMUNITY}",
+				Description: "SNMP Community (v1/v2 only)",
+			},
+			"securityname": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Security Name (v3 only)",
+			},
+			"securitylevel": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "noauthnopriv",
+				Description:  "Security Level (v3 only), one of: " + strings.Join(SNMP_SECLEVEL_ARR, ", "),
+				ValidateFunc: validation.StringInSlice(SNMP_SECLEVEL_ARR, false),
+			},
+			"authpassphrase": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Authentication Passphrase (v3 only)",
+			},
+			"privpassphrase": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Priv Passphrase (v3 only)",
+			},
+			"authprotocol": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "md5",
+				Description:  "Authentication Protocol (v3 only), one of: " + strings.Join(SNMP_AUTHPROTO_ARR, ", "),
+				ValidateFunc: validation.StringInSlice(SNMP_AUTHPROTO_ARR, false),
+			},
+			"privprotocol": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "des",
+				Description:  "Priv Protocol (v3 only), one of: " + strings.Join(SNMP_PRIVPROTO_ARR, ", "),
+				ValidateFunc: validation.StringInSlice(SNMP_PRIVPROTO_ARR, false),
+			},
+			"contextname": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Context Name (v3 only)",
+			},
+		},
+	},
+}
+
 // hostSchemaBase base host schema
 var hostSchemaBase = map[string]*schema.Schema{
 	"name": &schema.Schema{
@@ -46,10 +312,27 @@ var hostSchemaBase = map[string]*schema.Schema{
 		Description:  "FQDN of host",
 		ValidateFunc: validation.StringIsNotWhiteSpace,
 	},
+	"description": &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Host description",
+	},
 	"proxyid": &schema.Schema{
 		Type:        schema.TypeString,
 		Description: "ID of proxy to monitor this host",
 	},
+	"proxy_name": &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Name of proxy to monitor this host, alternative to proxyid",
+	},
+	"proxy_groupid": &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "ID of proxy group to monitor this host (zabbix 7.0+)",
+	},
+	"monitored_by": &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "Monitoring source, one of: " + strings.Join(HOST_MONITORED_BY_ARR, ", ") + ", derived from proxyid and proxy_groupid when unset",
+		ValidateFunc: validation.StringInSlice(HOST_MONITORED_BY_ARR, false),
+	},
 	"enabled": &schema.Schema{
 		Type:        schema.TypeBool,
 		Optional:    true,
@@ -81,7 +364,14 @@ var hostSchemaBase = map[string]*schema.Schema{
 					Type:        schema.TypeBool,
 					Optional:    true,
 					Default:     true,
-					Description: "Primary interface of this type",
+					Description: "Primary interface of this type, exactly one interface of each type must be main",
+				},
+				"useip": &schema.Schema{
+					Type:             schema.TypeBool,
+					Optional:         true,
+					Default:          true,
+					DiffSuppressFunc: interfaceUseIPDiffSuppress,
+					Description:      "Connect using the IP address when set, otherwise the DNS name",
 				},
 				"port": &schema.Schema{
 					Type:         schema.TypeInt,
@@ -90,6 +380,7 @@ var hostSchemaBase = map[string]*schema.Schema{
 					ValidateFunc: validation.IntBetween(0, 65535),
 					Description:  "Destination Port",
 				},
+				"details": hostInterfaceDetailsSchema,
 				"type": &schema.Schema{
 					Type:     schema.TypeString,
 					Optional: true,
@@ -122,20 +413,82 @@ var hostSchemaBase = map[string]*schema.Schema{
 		},
 	},
 	"macro": macroListSchema,
+	"tag":   tagSetSchema,
+	"inventory_mode": &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "Host inventory mode, one of: " + strings.Join(HOST_INVENTORY_MODES_ARR, ", "),
+		ValidateFunc: validation.StringInSlice(HOST_INVENTORY_MODES_ARR, false),
+	},
+	"inventory": hostInventorySchema(),
+	"ipmi_authtype": &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "IPMI authentication algorithm, one of: " + strings.Join(HOST_IPMI_AUTHTYPES_ARR, ", "),
+		ValidateFunc: validation.StringInSlice(HOST_IPMI_AUTHTYPES_ARR, false),
+	},
+	"ipmi_privilege": &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "IPMI privilege level, one of: " + strings.Join(HOST_IPMI_PRIVILEGES_ARR, ", "),
+		ValidateFunc: validation.StringInSlice(HOST_IPMI_PRIVILEGES_ARR, false),
+	},
+	"ipmi_username": &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "IPMI username",
+	},
+	"ipmi_password": &schema.Schema{
+		Type:        schema.TypeString,
+		Sensitive:   true,
+		Description: "IPMI password",
+	},
+	"tls_connect": &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "Connections to host, one of: " + strings.Join(HOST_TLS_TYPES_ARR, ", "),
+		ValidateFunc: validation.StringInSlice(HOST_TLS_TYPES_ARR, false),
+	},
+	"tls_accept": &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: "Connections from host, any of: " + strings.Join(HOST_TLS_TYPES_ARR, ", "),
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(HOST_TLS_TYPES_ARR, false),
+		},
+	},
+	"tls_psk_identity": &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "PSK identity",
+	},
+	"tls_psk": &schema.Schema{
+		Type:         schema.TypeString,
+		Sensitive:    true,
+		Description:  "PSK, at least 32 hex digits",
+		ValidateFunc: validation.StringMatch(regexp.MustCompile("^([0-9a-fA-F]{32,})?$"), "must be at least 32 hex digits"),
+	},
+	"tls_issuer": &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Certificate issuer",
+	},
+	"tls_subject": &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Certificate subject",
+	},
 }
 
 // resourceHost terraform host resource entrypoint
 func resourceHost() *schema.Resource {
-	return &schema.Resource{
+	return stateVersioned(&schema.Resource{
 		Create: resourceHostCreate,
 		Read:   resourceHostRead,
 		Update: resourceHostUpdate,
 		Delete: resourceHostDelete,
-		Schema: hostResourceSchema(hostSchemaBase),
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
-	}
+		CustomizeDiff: customdiff.All(
+			referenceCheck(map[string]string{
+				"groups":    "hostgroup",
+				"templates": "template",
+			}),
+			purgeCustomizeDiff,
+		),
+		Schema:   hostResourceSchema(hostSchemaBase),
+		Importer: importByName("host.get", "hostid", []string{"host", "name"}),
+	}, hostStateUpgradeV0)
 }
 
 // dataHost terraform host resource entrypoint
@@ -154,10 +507,14 @@ func hostResourceSchema(m map[string]*schema.Schema) (o map[string]*schema.Schem
 
 		// required
 		switch k {
-		case "host", "interface", "groups":
+		case "host", "interface":
 			schema.Required = true
-		case "templates", "proxyid":
+		case "templates", "description", "proxyid", "proxy_name", "proxy_groupid", "monitored_by", "inventory", "ipmi_username", "ipmi_password",
+			"tls_psk_identity", "tls_psk", "tls_issuer", "tls_subject":
 			schema.Optional = true
+		case "inventory_mode":
+			schema.Optional = true
+			schema.Computed = true
 		}
 
 		o[k] = &schema
@@ -165,6 +522,68 @@ func hostResourceSchema(m map[string]*schema.Schema) (o map[string]*schema.Schem
 
 	o["proxyid"].ValidateFunc = validation.StringIsNotWhiteSpace
 	o["proxyid"].Default = "0"
+	o["proxyid"].DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+		// proxy_name takes precedence when set
+		return d.Get("proxy_name").(string) != ""
+	}
+	o["proxy_name"].ValidateFunc = validation.StringIsNotWhiteSpace
+	o["groups"].Optional = true
+	o["groups"].Computed = true
+	o["groups"].ConflictsWith = []string{"group_names"}
+	o["groups"].AtLeastOneOf = []string{"groups", "group_names"}
+	o["group_names"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Hostgroup names to associate this host with, resolved to ids at apply time",
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+	}
+	o["group_names_create"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Create hostgroups named in group_names that do not exist",
+	}
+	o["templates"].Computed = true
+	o["templates"].ConflictsWith = []string{"template_names"}
+	o["template_names"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Template technical names to attach to this host, resolved to ids at apply time",
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+	}
+	o["proxy_groupid"].Default = "0"
+	o["ipmi_authtype"].Optional = true
+	o["ipmi_authtype"].Default = "default"
+	o["ipmi_privilege"].Optional = true
+	o["ipmi_privilege"].Default = "user"
+	o["tls_connect"].Optional = true
+	o["tls_connect"].Default = "unencrypted"
+	o["tls_accept"].Optional = true
+	o["tls_accept"].Computed = true
+
+	// behaviour flags
+	o["templates_clear_on_unlink"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Clear items and triggers inherited from templates when unlinking them",
+	}
+	o["deletion_protection"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Fail on destroy, deleting a host drops all of its history",
+	}
+	for k, v := range purgeSchema {
+		o[k] = v
+	}
+	o["item"] = hostItemSchema
 	return o
 }
 
@@ -179,49 +598,75 @@ func hostDataSchema(m map[string]*schema.Schema) (o map[string]*schema.Schema) {
 		case "host", "templates":
 			schema.Optional = true
 			fallthrough
-		case "interface", "groups", "macro", "proxyid":
+		case "interface", "groups", "macro", "tag", "description", "proxyid", "proxy_name", "proxy_groupid", "monitored_by",
+			"inventory_mode", "inventory",
+			"ipmi_authtype", "ipmi_privilege", "ipmi_username", "ipmi_password",
+			"tls_connect", "tls_accept", "tls_psk_identity", "tls_issuer", "tls_subject":
 			schema.Computed = true
 		}
 
+		// nothing to validate on computed only attributes
+		if !schema.Optional && !schema.Required {
+			schema.ValidateFunc = nil
+			schema.Default = nil
+		}
+
 		o[k] = &schema
 	}
 
+	// secrets are not exposed by the api
+	delete(o, "tls_psk")
+
 	// lookup vars
 	o["hostid"] = &schema.Schema{
 		Type:     schema.TypeString,
 		Optional: true,
 	}
+	for k, v := range searchSchema {
+		o[k] = v
+	}
 
 	return o
 }
 
 // hostGenerateInterfaces generate interface object array
-func hostGenerateInterfaces(d *schema.ResourceData) (interfaces zabbix.HostInterfaces, err error) {
+func hostGenerateInterfaces(d *schema.ResourceData, version serverVersion) (interfaces hostInterfaces, err error) {
 	interfaceCount := d.Get("interface.#").(int)
-	interfaces = make(zabbix.HostInterfaces, interfaceCount)
+	interfaces = make(hostInterfaces, interfaceCount)
+	mains := map[string]int{}
 
 	for i := 0; i < interfaceCount; i++ {
 		prefix := fmt.Sprintf("interface.%d.", i)
 		typeId := HOST_IFACE_TYPES[d.Get(prefix+"type").(string)]
 
-		interfaces[i] = zabbix.HostInterface{
-			IP:    d.Get(prefix + "ip").(string),
-			DNS:   d.Get(prefix + "dns").(string),
-			Main:  "0",
-			Type:  typeId,
-			UseIP: "0",
+		interfaces[i] = hostInterface{
+			HostInterface: zabbix.HostInterface{
+				IP:    d.Get(prefix + "ip").(string),
+				DNS:   d.Get(prefix + "dns").(string),
+				Main:  "0",
+				Type:  typeId,
+				UseIP: "0",
+			},
 		}
 		if interfaces[i].IP == "" && interfaces[i].DNS == "" {
 			err = errors.New("interface requires either an IP or DNS entry")
 			return
 		}
 
-		if interfaces[i].IP != "" {
+		if interfaces[i].IP != "" && d.Get(prefix+"useip").(bool) {
 			interfaces[i].UseIP = "1"
 		}
+		if interfaces[i].UseIP == "0" && interfaces[i].DNS == "" {
+			err = errors.New("interface with useip disabled requires a DNS entry")
+			return
+		}
 
+		if _, ok := mains[d.Get(prefix+"type").(string)]; !ok {
+			mains[d.Get(prefix+"type").(string)] = 0
+		}
 		if d.Get(prefix + "main").(bool) {
 			interfaces[i].Main = "1"
+			mains[d.Get(prefix+"type").(string)]++
 		}
 
 		// if no port set, set the default for the type
@@ -237,18 +682,182 @@ func hostGenerateInterfaces(d *schema.ResourceData) (interfaces zabbix.HostInter
 		if str := d.Get(prefix + "id").(string); str != "" {
 			interfaces[i].InterfaceID = str
 		}
+
+		if typeId == zabbix.SNMP && d.Get(prefix+"details.#").(int) > 0 {
+			interfaces[i].Details = hostGenerateInterfaceDetails(d, prefix+"details.0.")
+		} else if typeId == zabbix.SNMP && version.AtLeast(5, 0) {
+			// details are required from 5.0, use the block defaults
+			interfaces[i].Details = &hostInterfaceDetails{
+				Version:   "2",
+				Bulk:      "1",
+				Community: "{$SNMP_COMMUNITY}",
+			}
+		}
+	}
+
+	// zabbix requires a single default interface per type
+	for k, v := range mains {
+		if v != 1 {
+			err = fmt.Errorf("exactly one %s interface must be main, found %d", k, v)
+			return
+		}
 	}
 
 	return
 }
 
+// hostGenerateInterfaceDetails generate snmp details object
+func hostGenerateInterfaceDetails(d *schema.ResourceData, prefix string) *hostInterfaceDetails {
+	details := hostInterfaceDetails{
+		Version: d.Get(prefix + "version").(string),
+		Bulk:    "0",
+	}
+
+	if d.Get(prefix + "bulk").(bool) {
+		details.Bulk = "1"
+	}
+
+	switch details.Version {
+	case "1", "2":
+		details.Community = d.Get(prefix + "community").(string)
+	case "3":
+		details.SecurityName = d.Get(prefix + "securityname").(string)
+		details.SecurityLevel = SNMP_SECLEVEL[d.Get(prefix+"securitylevel").(string)]
+		details.AuthPassphrase = d.Get(prefix + "authpassphrase").(string)
+		details.PrivPassphrase = d.Get(prefix + "privpassphrase").(string)
+		details.AuthProtocol = SNMP_AUTHPROTO[d.Get(prefix+"authprotocol").(string)]
+		details.PrivProtocol = SNMP_PRIVPROTO[d.Get(prefix+"privprotocol").(string)]
+		details.ContextName = d.Get(prefix + "contextname").(string)
+	}
+
+	return &details
+}
+
+// hostInventoryFields non empty fields of an inventory block list
+func hostInventoryFields(v interface{}) map[string]string {
+	fields := map[string]string{}
+
+	list := v.([]interface{})
+	if len(list) < 1 || list[0] == nil {
+		return fields
+	}
+
+	for k, val := range list[0].(map[string]interface{}) {
+		if s := val.(string); s != "" {
+			fields[k] = s
+		}
+	}
+
+	return fields
+}
+
+// hostGenerateInventory generate inventory from the terraform inventory block
+func hostGenerateInventory(d *schema.ResourceData) hostInventory {
+	inventory := hostInventory{}
+
+	// the api keeps fields left out, clear those removed since the last apply
+	o, n := d.GetChange("inventory")
+	for k := range hostInventoryFields(o) {
+		inventory[k] = ""
+	}
+	for k, v := range hostInventoryFields(n) {
+		inventory[k] = v
+	}
+
+	return inventory
+}
+
+// hostMonitoredBy derive the monitoring source from proxy assignments
+func hostMonitoredBy(proxyid, proxygroupid string) string {
+	if proxygroupid != "" && proxygroupid != "0" {
+		return "proxy_group"
+	}
+	if proxyid != "" && proxyid != "0" {
+		return "proxy"
+	}
+	return "server"
+}
+
+// hostApplyMonitoredBy map proxy assignment onto the fields understood by the server version
+func hostApplyMonitoredBy(d *schema.ResourceData, api *zabbix.API, item *hostObject) error {
+	version, err := apiVersion(api)
+	if err != nil {
+		return err
+	}
+
+	monitoredBy := d.Get("monitored_by").(string)
+	if monitoredBy == "" {
+		monitoredBy = hostMonitoredBy(item.ProxyID, d.Get("proxy_groupid").(string))
+	}
+
+	if !version.AtLeast(7, 0) {
+		switch monitoredBy {
+		case "proxy_group":
+			return fmt.Errorf("proxy groups require zabbix 7.0 or newer, server is %s", version)
+		case "server":
+			item.ProxyID = "0"
+		}
+		return nil
+	}
+
+	item.MonitoredBy = HOST_MONITORED_BY[monitoredBy]
+	switch monitoredBy {
+	case "proxy":
+		item.MonitoredProxyID = item.ProxyID
+	case "proxy_group":
+		item.ProxyGroupID = d.Get("proxy_groupid").(string)
+	}
+
+	// proxy_hostid is no longer accepted
+	item.ProxyID = ""
+
+	return nil
+}
+
 // buildHostObject create host struct
-func buildHostObject(d *schema.ResourceData) (*zabbix.Host, error) {
-	item := zabbix.Host{
-		Host:    d.Get("host").(string),
-		Name:    d.Get("name").(string),
-		ProxyID: d.Get("proxyid").(string),
-		Status:  0,
+func buildHostObject(d *schema.ResourceData, meta *providerMeta) (*hostObject, error) {
+	api := meta.API
+
+	item := hostObject{
+		Host: zabbix.Host{
+			Host:    d.Get("host").(string),
+			Name:    d.Get("name").(string),
+			ProxyID: d.Get("proxyid").(string),
+			Status:  0,
+		},
+		Description:   d.Get("description").(string),
+		IPMIAuthType:  HOST_IPMI_AUTHTYPES[d.Get("ipmi_authtype").(string)],
+		IPMIPrivilege: HOST_IPMI_PRIVILEGES[d.Get("ipmi_privilege").(string)],
+		IPMIUsername:  d.Get("ipmi_username").(string),
+		IPMIPassword:  d.Get("ipmi_password").(string),
+		TLSConnect:    HOST_TLS_TYPES[d.Get("tls_connect").(string)],
+		TLSIssuer:     d.Get("tls_issuer").(string),
+		TLSSubject:    d.Get("tls_subject").(string),
+	}
+
+	// psk settings are only sent when set, or cleared when the encryption changes
+	tlsChange := d.HasChange("tls_connect") || d.HasChange("tls_accept")
+	if v := d.Get("tls_psk_identity").(string); v != "" || tlsChange {
+		item.TLSPSKIdentity = &v
+	}
+	if v := d.Get("tls_psk").(string); v != "" || tlsChange {
+		item.TLSPSK = &v
+	}
+
+	if v := d.Get("proxy_name").(string); v != "" {
+		proxyid, err := proxyIdLookup(api, v)
+		if err != nil {
+			return nil, err
+		}
+		item.ProxyID = proxyid
+	}
+
+	if err := hostApplyMonitoredBy(d, api, &item); err != nil {
+		return nil, err
+	}
+
+	for _, v := range d.Get("tls_accept").(*schema.Set).List() {
+		item.TLSAccept |= HOST_TLS_TYPES[v.(string)]
 	}
 
 	if !d.Get("enabled").(bool) {
@@ -256,9 +865,23 @@ func buildHostObject(d *schema.ResourceData) (*zabbix.Host, error) {
 	}
 
 	item.GroupIds = buildHostGroupIds(d.Get("groups").(*schema.Set))
+	if names := setToStrings(d.Get("group_names").(*schema.Set)); len(names) > 0 {
+		groups, err := hostgroupIdsLookup(meta, names, d.Get("group_names_create").(bool))
+		if err != nil {
+			return nil, err
+		}
+		item.GroupIds = groups
+	}
 	item.TemplateIDs = buildTemplateIds(d.Get("templates").(*schema.Set))
+	if names := setToStrings(d.Get("template_names").(*schema.Set)); len(names) > 0 {
+		templates, err := templateIdsLookup(meta.API, names)
+		if err != nil {
+			return nil, err
+		}
+		item.TemplateIDs = templates
+	}
 
-	interfaces, err := hostGenerateInterfaces(d)
+	interfaces, err := hostGenerateInterfaces(d, meta.Version)
 
 	if err != nil {
 		return nil, err
@@ -267,6 +890,19 @@ func buildHostObject(d *schema.ResourceData) (*zabbix.Host, error) {
 	item.Interfaces = interfaces
 	item.UserMacros = macroGenerate(d)
 
+	// only send tags when managed, an empty list clears them
+	if tags := tagsWithDefaults(tagGenerate(d), meta.DefaultTags); len(tags) > 0 || d.HasChange("tag") {
+		item.Tags = &tags
+	}
+
+	if v := d.Get("inventory_mode").(string); v != "" {
+		item.InventoryMode = HOST_INVENTORY_MODES[v]
+	}
+	// inventory is rejected by the api while disabled
+	if item.InventoryMode != HOST_INVENTORY_MODES["disabled"] {
+		item.Inventory = hostGenerateInventory(d)
+	}
+
 	log.Trace("build host object: %#v", item)
 
 	return &item, nil
@@ -274,17 +910,20 @@ func buildHostObject(d *schema.ResourceData) (*zabbix.Host, error) {
 
 // resourceHostCreate terraform create handler
 func resourceHostCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	meta := m.(*providerMeta)
+	api := meta.API
 
-	item, err := buildHostObject(d)
+	item, err := buildHostObject(d, meta)
 
 	if err != nil {
 		return err
 	}
 
-	items := []zabbix.Host{*item}
+	items := []hostObject{*item}
 
-	err = api.HostsCreate(items)
+	err = apiRetry(m, func() error {
+		return hostsCreate(api, items)
+	})
 
 	if err != nil {
 		return err
@@ -294,6 +933,10 @@ func resourceHostCreate(d *schema.ResourceData, m interface{}) error {
 
 	d.SetId(items[0].HostID)
 
+	if err := hostItemsApply(d, m); err != nil {
+		return err
+	}
+
 	return resourceHostRead(d, m)
 }
 
@@ -301,9 +944,11 @@ func resourceHostCreate(d *schema.ResourceData, m interface{}) error {
 func dataHostRead(d *schema.ResourceData, m interface{}) error {
 	params := zabbix.Params{
 		"selectInterfaces":      "extend",
-		"selectParentTemplates": "extend",
-		"selectGroups":          "extend",
+		"selectParentTemplates": []string{"templateid"},
+		"selectGroups":          []string{"groupid"},
 		"selectMacros":          "extend",
+		"selectInventory":       "extend",
+		"selectTags":            "extend",
 		"filter":                map[string]interface{}{},
 	}
 
@@ -314,34 +959,93 @@ func dataHostRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	if len(params["filter"].(map[string]interface{})) < 1 {
+	if searchEmpty(d, params) {
 		return errors.New("no host lookup attribute")
 	}
+	searchParams(d, params, "hostid")
 	log.Debug("performing data lookup with params: %#v", params)
 
-	return hostRead(d, m, params)
+	if err := hostRead(d, m, params); err != nil {
+		return err
+	}
+
+	name, err := proxyNameLookup(m.(*providerMeta).API, d.Get("proxyid").(string))
+	if err != nil {
+		return err
+	}
+	d.Set("proxy_name", name)
+	d.Set("monitored_by", hostMonitoredBy(d.Get("proxyid").(string), d.Get("proxy_groupid").(string)))
+
+	return nil
 }
 
 // resourceHostRead read handler for resource
 func resourceHostRead(d *schema.ResourceData, m interface{}) error {
 	log.Debug("Lookup of hostgroup with id %s", d.Id())
 
-	return hostRead(d, m, zabbix.Params{
+	own := tagGenerate(d)
+	ownInventory := hostInventoryFields(d.Get("inventory"))
+
+	err := hostRead(d, m, zabbix.Params{
 		"selectInterfaces":      "extend",
-		"selectParentTemplates": "extend",
-		"selectGroups":          "extend",
+		"selectParentTemplates": []string{"templateid"},
+		"selectGroups":          []string{"groupid"},
 		"selectMacros":          "extend",
+		"selectInventory":       "extend",
+		"selectTags":            "extend",
 		"hostids":               d.Id(),
 	})
+	if err != nil {
+		return err
+	}
+
+	// provider default tags are not part of the resource config
+	tags := tagsWithoutDefaults(tagGenerate(d), m.(*providerMeta).DefaultTags, own)
+	d.Set("tag", flattenTags(tags))
+
+	// items fill in automatic inventory, only track the configured fields
+	if d.Get("inventory_mode").(string) == "automatic" {
+		server := hostInventoryFields(d.Get("inventory"))
+		inventory := hostInventory{}
+		for k := range ownInventory {
+			inventory[k] = server[k]
+		}
+		d.Set("inventory", flattenHostInventory(hostObject{Inventory: inventory}))
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	// only track group and template names when used
+	if d.Get("group_names").(*schema.Set).Len() > 0 {
+		names, err := hostgroupNamesLookup(m.(*providerMeta).API, setToStrings(d.Get("groups").(*schema.Set)))
+		if err != nil {
+			return err
+		}
+		d.Set("group_names", schema.NewSet(schema.HashString, names))
+	}
+	if d.Get("template_names").(*schema.Set).Len() > 0 {
+		names, err := templateNamesLookup(m.(*providerMeta).API, setToStrings(d.Get("templates").(*schema.Set)))
+		if err != nil {
+			return err
+		}
+		d.Set("template_names", schema.NewSet(schema.HashString, names))
+	}
+	if err := hostItemsRead(d, m); err != nil {
+		return err
+	}
+
+	return purgeRead(d, m)
 }
 
 // hostRead common host read function
 func hostRead(d *schema.ResourceData, m interface{}, params zabbix.Params) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
 
 	log.Debug("Lookup of host with params %#v", params)
 
-	hosts, err := api.HostsGet(params)
+	hosts, err := hostsGet(api, params)
 
 	if err != nil {
 		return err
@@ -361,10 +1065,59 @@ func hostRead(d *schema.ResourceData, m interface{}, params zabbix.Params) error
 	d.SetId(host.HostID)
 	d.Set("name", host.Name)
 	d.Set("host", host.Host)
+	d.Set("description", host.Description)
+	// zabbix 7.0 renamed proxy_hostid
+	if host.MonitoredProxyID != "" {
+		host.ProxyID = host.MonitoredProxyID
+	}
+	if host.ProxyID == "" {
+		host.ProxyID = "0"
+	}
+	if host.ProxyGroupID == "" {
+		host.ProxyGroupID = "0"
+	}
 	d.Set("proxyid", host.ProxyID)
+	d.Set("proxy_groupid", host.ProxyGroupID)
+
+	// only track monitored_by when configured, it is otherwise derived
+	if d.Get("monitored_by").(string) != "" {
+		d.Set("monitored_by", hostMonitoredBy(host.ProxyID, host.ProxyGroupID))
+	}
+
+	// only track the proxy name when used
+	if d.Get("proxy_name").(string) != "" {
+		name, err := proxyNameLookup(api, host.ProxyID)
+		if err != nil {
+			return err
+		}
+		d.Set("proxy_name", name)
+	}
 	d.Set("enabled", host.Status == 0)
+	d.Set("ipmi_authtype", HOST_IPMI_AUTHTYPES_REV[host.IPMIAuthType])
+	d.Set("ipmi_privilege", HOST_IPMI_PRIVILEGES_REV[host.IPMIPrivilege])
+	d.Set("ipmi_username", host.IPMIUsername)
+	d.Set("ipmi_password", host.IPMIPassword)
+	d.Set("tls_connect", HOST_TLS_TYPES_REV[host.TLSConnect])
+	d.Set("tls_issuer", host.TLSIssuer)
+	d.Set("tls_subject", host.TLSSubject)
+
+	acceptSet := schema.NewSet(schema.HashString, []interface{}{})
+	for k, v := range HOST_TLS_TYPES {
+		if host.TLSAccept&v != 0 {
+			acceptSet.Add(k)
+		}
+	}
+	d.Set("tls_accept", acceptSet)
+
+	// zabbix 5.4+ no longer returns the psk nor its identity, keep the configured values
+	if host.TLSPSKIdentity != nil && *host.TLSPSKIdentity != "" {
+		d.Set("tls_psk_identity", *host.TLSPSKIdentity)
+	}
+	if host.TLSPSK != nil && *host.TLSPSK != "" {
+		d.Set("tls_psk", *host.TLSPSK)
+	}
 
-	d.Set("interface", flattenHostInterfaces(host))
+	d.Set("interface", flattenHostInterfaces(host, d))
 
 	templateSet := schema.NewSet(schema.HashString, []interface{}{})
 	for _, v := range host.ParentTemplateIDs {
@@ -378,33 +1131,127 @@ func hostRead(d *schema.ResourceData, m interface{}, params zabbix.Params) error
 	}
 	d.Set("groups", groupSet)
 
-	d.Set("macro", flattenMacros(host.UserMacros))
+	d.Set("macro", flattenMacros(host.UserMacros, d))
+
+	tags := zabbix.Tags{}
+	if host.Tags != nil {
+		tags = *host.Tags
+	}
+	d.Set("tag", flattenTags(tags))
+
+	// older api versions report the mode within the inventory object
+	mode := host.InventoryMode
+	if mode == "" {
+		mode = host.Inventory["inventory_mode"]
+	}
+	if mode == "" {
+		mode = HOST_INVENTORY_MODES["disabled"]
+	}
+	d.Set("inventory_mode", HOST_INVENTORY_MODES_REV[mode])
+	d.Set("inventory", flattenHostInventory(host))
 
 	return nil
 }
 
+// flattenHostInventory convert API response into terraform structs
+func flattenHostInventory(host hostObject) []interface{} {
+	val := map[string]interface{}{}
+	populated := false
+
+	for _, k := range HOST_INVENTORY_FIELDS {
+		val[k] = host.Inventory[k]
+		if host.Inventory[k] != "" {
+			populated = true
+		}
+	}
+
+	// an all empty inventory is equivalent to no inventory block
+	if !populated {
+		return []interface{}{}
+	}
+
+	return []interface{}{val}
+}
+
 // flattenHostInterfaces convert API response into terraform structs
-func flattenHostInterfaces(host zabbix.Host) []interface{} {
-	val := make([]interface{}, len(host.Interfaces))
-	for i := 0; i < len(host.Interfaces); i++ {
-		port, _ := strconv.ParseInt(host.Interfaces[i].Port, 10, 64)
+func flattenHostInterfaces(host hostObject, d *schema.ResourceData) []interface{} {
+	interfaces := hostSortInterfaces(host.Interfaces, d)
+
+	val := make([]interface{}, len(interfaces))
+	for i := 0; i < len(interfaces); i++ {
+		port, _ := strconv.ParseInt(interfaces[i].Port, 10, 64)
 		val[i] = map[string]interface{}{
-			"id":   host.Interfaces[i].InterfaceID,
-			"ip":   host.Interfaces[i].IP,
-			"dns":  host.Interfaces[i].DNS,
-			"main": host.Interfaces[i].Main == "1",
-			"port": port,
-			"type": HOST_IFACE_TYPES_REV[host.Interfaces[i].Type],
+			"id":      interfaces[i].InterfaceID,
+			"ip":      interfaces[i].IP,
+			"dns":     interfaces[i].DNS,
+			"main":    interfaces[i].Main == "1",
+			"useip":   interfaces[i].UseIP == "1",
+			"port":    port,
+			"type":    HOST_IFACE_TYPES_REV[interfaces[i].Type],
+			"details": flattenHostInterfaceDetails(interfaces[i].Details),
 		}
 	}
 	return val
 }
 
+// flattenHostInterfaceDetails convert snmp details into terraform structs
+func flattenHostInterfaceDetails(details *hostInterfaceDetails) []interface{} {
+	if details == nil || details.Version == "" {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"version":        details.Version,
+			"bulk":           details.Bulk == "1",
+			"community":      details.Community,
+			"securityname":   details.SecurityName,
+			"securitylevel":  SNMP_SECLEVEL_REV[details.SecurityLevel],
+			"authpassphrase": details.AuthPassphrase,
+			"privpassphrase": details.PrivPassphrase,
+			"authprotocol":   SNMP_AUTHPROTO_REV[details.AuthProtocol],
+			"privprotocol":   SNMP_PRIVPROTO_REV[details.PrivProtocol],
+			"contextname":    details.ContextName,
+		},
+	}
+}
+
+// hostSortInterfaces order interfaces as they are in state, the api does not preserve ordering
+func hostSortInterfaces(list hostInterfaces, d *schema.ResourceData) hostInterfaces {
+	byId := map[string]hostInterface{}
+	for _, v := range list {
+		byId[v.InterfaceID] = v
+	}
+
+	sorted := hostInterfaces{}
+	for i := 0; i < d.Get("interface.#").(int); i++ {
+		id := d.Get(fmt.Sprintf("interface.%d.id", i)).(string)
+		if v, ok := byId[id]; ok {
+			sorted = append(sorted, v)
+			delete(byId, id)
+		}
+	}
+
+	// anything new, in api order
+	for _, v := range list {
+		if _, ok := byId[v.InterfaceID]; ok {
+			sorted = append(sorted, v)
+		}
+	}
+
+	return sorted
+}
+
 // resourceHostUpdate terraform update resource handler
 func resourceHostUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	meta := m.(*providerMeta)
+	api := meta.API
+
+	if err := objectEditable(api, "host", d.Id()); err != nil {
+		return err
+	}
 
-	item, err := buildHostObject(d)
+	item, err := buildHostObject(d, meta)
 
 	if err != nil {
 		return err
@@ -412,19 +1259,102 @@ func resourceHostUpdate(d *schema.ResourceData, m interface{}) error {
 
 	item.HostID = d.Id()
 
-	items := []zabbix.Host{*item}
+	if d.Get("templates_clear_on_unlink").(bool) && (d.HasChange("templates") || d.HasChange("template_names")) {
+		// compare against the resolved ids, templates is only computed when names are used
+		o, _ := d.GetChange("templates")
+		n := schema.NewSet(schema.HashString, []interface{}{})
+		for _, v := range item.TemplateIDs {
+			n.Add(v.TemplateID)
+		}
+		item.TemplateIDsClear = buildTemplateIds(o.(*schema.Set).Difference(n))
+	}
 
-	err = api.HostsUpdate(items)
+	items := []hostObject{*item}
+
+	err = apiRetryLocked(m, hostLockKey(d.Id()), func() error {
+		return hostsUpdate(api, items)
+	})
 
 	if err != nil {
 		return err
 	}
 
+	if err := hostItemsApply(d, m); err != nil {
+		return err
+	}
+	if err := purgeApply(d, m); err != nil {
+		return err
+	}
+
 	return resourceHostRead(d, m)
 }
 
+// hostsGet fetch hosts, including attributes not modelled by the library
+func hostsGet(api *zabbix.API, params zabbix.Params) (hosts []hostObject, err error) {
+	if _, ok := params["output"]; !ok {
+		params["output"] = "extend"
+	}
+	err = apiGet(api, "host.get", params, &hosts)
+	return
+}
+
+// hostsCreate create hosts, populating the generated host ids
+func hostsCreate(api *zabbix.API, hosts []hostObject) error {
+	response, err := api.CallWithError("host.create", hosts)
+
+	if err != nil {
+		return err
+	}
+
+	result := response.Result.(map[string]interface{})
+	hostids := result["hostids"].([]interface{})
+	for i, id := range hostids {
+		hosts[i].HostID = id.(string)
+	}
+
+	return nil
+}
+
+// hostsUpdate update hosts
+func hostsUpdate(api *zabbix.API, hosts []hostObject) error {
+	_, err := api.CallWithError("host.update", hosts)
+	return err
+}
+
 // resourceHostDelete terraform delete resource handler
 func resourceHostDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
-	return api.HostsDeleteByIds([]string{d.Id()})
+	api := m.(*providerMeta).API
+
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("host %s has deletion_protection enabled, disable it and apply before destroying", d.Get("host"))
+	}
+	return apiRetryLocked(m, hostLockKey(d.Id()), func() error {
+		return api.HostsDeleteByIds([]string{d.Id()})
+	})
+}
+
+// hostIdLookup resolve a host or template technical name to its id, "" when missing
+func hostIdLookup(meta *providerMeta, name string) (string, error) {
+	if id, ok := meta.HostIDs.get(name); ok {
+		return id, nil
+	}
+
+	var hosts []map[string]interface{}
+	err := apiGet(meta.API, "host.get", zabbix.Params{
+		"output":          []string{"hostid", "host"},
+		"filter":          map[string]interface{}{"host": []string{name}},
+		"templated_hosts": true,
+	}, &hosts)
+	if err != nil {
+		return "", err
+	}
+
+	// missing hosts are not cached, they may be created later in the run
+	if len(hosts) < 1 {
+		return "", nil
+	}
+	id, _ := hosts[0]["hostid"].(string)
+	meta.HostIDs.set(name, id)
+
+	return id, nil
 }
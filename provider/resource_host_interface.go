@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// dataHostInterface terraform host interface data source entrypoint
+func dataHostInterface() *schema.Resource {
+	ifaceTypes := []string{}
+	for k := range HOST_IFACE_TYPES {
+		ifaceTypes = append(ifaceTypes, k)
+	}
+	sort.Strings(ifaceTypes)
+
+	return &schema.Resource{
+		Read: dataHostInterfaceRead,
+
+		Schema: map[string]*schema.Schema{
+			"hostid": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Host ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "agent",
+				Description:  "Interface type, one of: " + strings.Join(ifaceTypes, ", "),
+				ValidateFunc: validation.StringInSlice(ifaceTypes, false),
+			},
+			"main": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Only match the primary interface of this type",
+			},
+			"dns": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Interface DNS name",
+			},
+			"ip": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Interface IP address",
+			},
+			"port": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Interface port",
+			},
+			"useip": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Connect using the IP address",
+			},
+		},
+	}
+}
+
+// dataHostInterfaceRead read handler for data resource
+func dataHostInterfaceRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	filter := map[string]interface{}{
+		"type": HOST_IFACE_TYPES[d.Get("type").(string)],
+	}
+	if d.Get("main").(bool) {
+		filter["main"] = "1"
+	}
+	for _, k := range []string{"dns", "ip"} {
+		if v, ok := d.GetOk(k); ok {
+			filter[k] = v
+		}
+	}
+
+	params := zabbix.Params{
+		"output":  "extend",
+		"hostids": d.Get("hostid"),
+		"filter":  filter,
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var interfaces hostInterfaces
+	if err := apiGet(api, "hostinterface.get", params, &interfaces); err != nil {
+		return err
+	}
+
+	if len(interfaces) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(interfaces) > 1 {
+		return errors.New("multiple host interfaces found")
+	}
+	iface := interfaces[0]
+
+	log.Debug("Got host interface: %+v", iface)
+
+	port, _ := strconv.ParseInt(iface.Port, 10, 64)
+
+	d.SetId(iface.InterfaceID)
+	d.Set("dns", iface.DNS)
+	d.Set("ip", iface.IP)
+	d.Set("port", port)
+	d.Set("useip", iface.UseIP == "1")
+
+	return nil
+}
@@ -2,6 +2,8 @@ package provider
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -11,13 +13,11 @@ import (
 // resourceHostgroup terraform resource handler
 func resourceHostgroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceHostgroupCreate,
-		Read:   resourceHostgroupRead,
-		Update: resourceHostgroupUpdate,
-		Delete: resourceHostgroupDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Create:   resourceHostgroupCreate,
+		Read:     resourceHostgroupRead,
+		Update:   resourceHostgroupUpdate,
+		Delete:   resourceHostgroupDelete,
+		Importer: importByName("hostgroup.get", "groupid", []string{"name"}),
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -35,20 +35,21 @@ func dataHostgroup() *schema.Resource {
 	return &schema.Resource{
 		Read: dataHostgroupRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: mergeSchemas(searchSchema, map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:         schema.TypeString,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 				Description:  "Hostgroup Name",
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 			},
-		},
+		}),
 	}
 }
 
 // terraform hostgroup create function
 func resourceHostgroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
 
 	item := zabbix.HostGroup{
 		Name: d.Get("name").(string),
@@ -56,7 +57,9 @@ func resourceHostgroupCreate(d *schema.ResourceData, m interface{}) error {
 
 	items := []zabbix.HostGroup{item}
 
-	err := api.HostGroupsCreate(items)
+	err := apiRetryLocked(m, "hostgroup", func() error {
+		return api.HostGroupsCreate(items)
+	})
 
 	if err != nil {
 		return err
@@ -71,7 +74,7 @@ func resourceHostgroupCreate(d *schema.ResourceData, m interface{}) error {
 
 // hostgroupRead terraform hostgroup read function
 func hostgroupRead(d *schema.ResourceData, m interface{}, params zabbix.Params) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
 
 	hostgroups, err := api.HostGroupsGet(params)
 
@@ -98,11 +101,20 @@ func hostgroupRead(d *schema.ResourceData, m interface{}, params zabbix.Params)
 
 // dataHostgroupRead terraform data resource read handler
 func dataHostgroupRead(d *schema.ResourceData, m interface{}) error {
-	return hostgroupRead(d, m, zabbix.Params{
-		"filter": map[string]interface{}{
-			"name": d.Get("name"),
-		},
-	})
+	params := zabbix.Params{
+		"filter": map[string]interface{}{},
+	}
+
+	if v := d.Get("name").(string); v != "" {
+		params["filter"].(map[string]interface{})["name"] = v
+	}
+
+	if searchEmpty(d, params) {
+		return errors.New("no hostgroup lookup attribute")
+	}
+	searchParams(d, params, "groupid")
+
+	return hostgroupRead(d, m, params)
 }
 
 // resourceHostgroupRead terraform resource read handler
@@ -116,7 +128,7 @@ func resourceHostgroupRead(d *schema.ResourceData, m interface{}) error {
 
 // resourceHostgroupUpdate terraform resource update handler
 func resourceHostgroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
 
 	item := zabbix.HostGroup{
 		GroupID: d.Id(),
@@ -125,7 +137,9 @@ func resourceHostgroupUpdate(d *schema.ResourceData, m interface{}) error {
 
 	items := []zabbix.HostGroup{item}
 
-	err := api.HostGroupsUpdate(items)
+	err := apiRetryLocked(m, "hostgroup", func() error {
+		return api.HostGroupsUpdate(items)
+	})
 
 	if err != nil {
 		return err
@@ -136,6 +150,107 @@ func resourceHostgroupUpdate(d *schema.ResourceData, m interface{}) error {
 
 // resourceHostgroupDelete terraform resource delete handler
 func resourceHostgroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
-	return api.HostGroupsDeleteByIds([]string{d.Id()})
+	api := m.(*providerMeta).API
+	return apiRetryLocked(m, "hostgroup", func() error {
+		return api.HostGroupsDeleteByIds([]string{d.Id()})
+	})
+}
+
+// serializes creating missing hostgroups, hosts sharing a new group name would otherwise race
+var hostgroupCreateLock sync.Mutex
+
+// hostgroupsByName ids of the named hostgroups, and the names missing
+func hostgroupsByName(api *zabbix.API, names []string) (map[string]string, zabbix.HostGroups, error) {
+	var hostgroups zabbix.HostGroups
+	err := apiGet(api, "hostgroup.get", zabbix.Params{
+		"output": []string{"groupid", "name"},
+		"filter": map[string]interface{}{"name": names},
+	}, &hostgroups)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byName := map[string]string{}
+	for _, v := range hostgroups {
+		byName[v.Name] = v.GroupID
+	}
+
+	missing := zabbix.HostGroups{}
+	for _, name := range names {
+		if _, ok := byName[name]; !ok {
+			missing = append(missing, zabbix.HostGroup{Name: name})
+		}
+	}
+
+	return byName, missing, nil
+}
+
+// hostgroupIdsLookup resolve hostgroup names to ids, optionally creating missing groups
+func hostgroupIdsLookup(m interface{}, names []string, create bool) (zabbix.HostGroupIDs, error) {
+	api := m.(*providerMeta).API
+
+	byName, missing, err := hostgroupsByName(api, names)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(missing) > 0 {
+		if !create {
+			return nil, fmt.Errorf("hostgroup %s does not exist", missing[0].Name)
+		}
+
+		hostgroupCreateLock.Lock()
+		defer hostgroupCreateLock.Unlock()
+
+		// another resource may have created them while waiting
+		readCacheClear(api)
+		if byName, missing, err = hostgroupsByName(api, names); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(missing) > 0 {
+		err := apiRetryLocked(m, "hostgroup", func() error {
+			return api.HostGroupsCreate(missing)
+		})
+		if err != nil {
+			// created outside this provider in the meantime, reuse them
+			var stillMissing zabbix.HostGroups
+			byName, stillMissing, _ = hostgroupsByName(api, names)
+			if byName == nil || len(stillMissing) > 0 {
+				return nil, err
+			}
+			missing = nil
+		}
+		for _, v := range missing {
+			log.Debug("created hostgroup %s: %s", v.Name, v.GroupID)
+			byName[v.Name] = v.GroupID
+		}
+	}
+
+	ids := make(zabbix.HostGroupIDs, len(names))
+	for i, name := range names {
+		ids[i] = zabbix.HostGroupID{GroupID: byName[name]}
+	}
+
+	return ids, nil
+}
+
+// hostgroupNamesLookup resolve hostgroup ids to names
+func hostgroupNamesLookup(api *zabbix.API, ids []string) ([]interface{}, error) {
+	var hostgroups zabbix.HostGroups
+	err := apiGet(api, "hostgroup.get", zabbix.Params{
+		"output":   []string{"groupid", "name"},
+		"groupids": ids,
+	}, &hostgroups)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]interface{}, len(hostgroups))
+	for i, v := range hostgroups {
+		names[i] = v.Name
+	}
+
+	return names, nil
 }
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform/helper/hashcode"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// dataHostgroups terraform data source returning all matching hostgroups
+func dataHostgroups() *schema.Resource {
+	return &schema.Resource{
+		Read: dataHostgroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Only return hostgroups with a name matching, * is a wildcard",
+			},
+			"hostgroups": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"groupid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Matching hostgroups",
+			},
+		},
+	}
+}
+
+// dataHostgroupsRead read handler for data resource
+func dataHostgroupsRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"output":    []string{"groupid", "name"},
+		"sortfield": "name",
+	}
+
+	if v, ok := d.GetOk("name"); ok {
+		params["search"] = map[string]interface{}{
+			"name": v,
+		}
+		params["searchWildcardsEnabled"] = true
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	groups, err := api.HostGroupsGet(params)
+	if err != nil {
+		return err
+	}
+
+	list := make([]interface{}, len(groups))
+	for i, group := range groups {
+		list[i] = map[string]interface{}{
+			"groupid": group.GroupID,
+			"name":    group.Name,
+		}
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%v", params))))
+	d.Set("hostgroups", list)
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform/helper/hashcode"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// dataHosts terraform data source returning all matching hosts
+func dataHosts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataHostsRead,
+
+		Schema: map[string]*schema.Schema{
+			"groupids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Description: "Only return hosts in these hostgroups",
+			},
+			"proxyids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Description: "Only return hosts monitored by these proxies",
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Only return hosts with a visible name matching, * is a wildcard",
+			},
+			"tag": tagSetSchema,
+			"hosts": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"interface": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"dns": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"ip": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"main": &schema.Schema{
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"port": &schema.Schema{
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"type": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+				Description: "Matching hosts",
+			},
+		},
+	}
+}
+
+// dataHostsRead read handler for data resource
+func dataHostsRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"output":           []string{"hostid", "host", "name", "status"},
+		"selectInterfaces": []string{"interfaceid", "ip", "dns", "port", "type", "main"},
+		"sortfield":        "host",
+	}
+
+	if v := d.Get("groupids").(*schema.Set); v.Len() > 0 {
+		params["groupids"] = v.List()
+	}
+	if v := d.Get("proxyids").(*schema.Set); v.Len() > 0 {
+		params["proxyids"] = v.List()
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params["search"] = map[string]interface{}{
+			"name": v,
+		}
+		params["searchWildcardsEnabled"] = true
+	}
+	if tags := tagGenerate(d); len(tags) > 0 {
+		params["tags"] = tagFilterGenerate(tags)
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	hosts, err := hostsGet(api, params)
+	if err != nil {
+		return err
+	}
+
+	list := make([]interface{}, len(hosts))
+	for i, host := range hosts {
+		interfaces := make([]interface{}, len(host.Interfaces))
+		for j, iface := range host.Interfaces {
+			port, _ := strconv.ParseInt(iface.Port, 10, 64)
+			interfaces[j] = map[string]interface{}{
+				"id":   iface.InterfaceID,
+				"dns":  iface.DNS,
+				"ip":   iface.IP,
+				"main": iface.Main == "1",
+				"port": port,
+				"type": HOST_IFACE_TYPES_REV[iface.Type],
+			}
+		}
+
+		list[i] = map[string]interface{}{
+			"hostid":    host.HostID,
+			"host":      host.Host,
+			"name":      host.Name,
+			"enabled":   host.Status == 0,
+			"interface": interfaces,
+		}
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%v", params))))
+	d.Set("hosts", list)
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// dataItem terraform item data source entrypoint, usable with any item type
+func dataItem() *schema.Resource {
+	return &schema.Resource{
+		Read: dataItemRead,
+
+		Schema: map[string]*schema.Schema{
+			"itemid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Item ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"hostid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Host ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"key": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Item KEY",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Item Name",
+			},
+			"valuetype": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Item Value Type",
+			},
+			"delay": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Item Delay period",
+			},
+			"interfaceid": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Host Interface ID",
+			},
+		},
+	}
+}
+
+// dataItemRead read handler for data resource
+func dataItemRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"filter": map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("itemid"); ok {
+		params["itemids"] = v
+	} else {
+		hostid, hok := d.GetOk("hostid")
+		key, kok := d.GetOk("key")
+		if !hok || !kok {
+			return errors.New("itemid, or both hostid and key, are required")
+		}
+		params["hostids"] = hostid
+		params["filter"].(map[string]interface{})["key_"] = key
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	items, err := api.ItemsGet(params)
+	if err != nil {
+		return err
+	}
+
+	if len(items) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(items) > 1 {
+		return errors.New("multiple items found")
+	}
+	item := items[0]
+
+	log.Debug("Got item: %+v", item)
+
+	d.SetId(item.ItemID)
+	d.Set("itemid", item.ItemID)
+	d.Set("hostid", item.HostID)
+	d.Set("key", item.Key)
+	d.Set("name", item.Name)
+	d.Set("valuetype", ITEM_VALUE_TYPES_REV[item.ValueType])
+	d.Set("delay", item.Delay)
+	d.Set("interfaceid", item.InterfaceID)
+
+	return nil
+}
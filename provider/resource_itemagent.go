@@ -7,14 +7,13 @@ import (
 
 // resourceItemAgent terraform resource for agent items
 func resourceItemAgent() *schema.Resource {
-	return &schema.Resource{
-		Create: itemGetCreateWrapper(itemAgentModFunc, itemAgentReadFunc),
-		Read:   itemGetReadWrapper(itemAgentReadFunc),
-		Update: itemGetUpdateWrapper(itemAgentModFunc, itemAgentReadFunc),
-		Delete: resourceItemDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+	return stateVersioned(&schema.Resource{
+		Create:        itemGetCreateWrapper(itemAgentModFunc, itemAgentReadFunc),
+		Read:          itemGetReadWrapper(itemAgentReadFunc),
+		Update:        itemGetUpdateWrapper(itemAgentModFunc, itemAgentReadFunc),
+		Delete:        resourceItemDelete,
+		CustomizeDiff: itemReferenceCheck,
+		Importer:      itemImporter(),
 
 		Schema: mergeSchemas(itemCommonSchema, itemDelaySchema, itemInterfaceSchema, map[string]*schema.Schema{
 			"active": &schema.Schema{
@@ -24,7 +23,7 @@ func resourceItemAgent() *schema.Resource {
 				Default:     false,
 			},
 		}),
-	}
+	}, nil)
 }
 
 func itemAgentModFunc(d *schema.ResourceData, item *zabbix.Item) {
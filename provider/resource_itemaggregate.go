@@ -7,17 +7,16 @@ import (
 
 // terraform resource handler for item type
 func resourceItemAggregate() *schema.Resource {
-	return &schema.Resource{
-		Create: itemGetCreateWrapper(itemAggregateModFunc, itemAggregateReadFunc),
-		Read:   itemGetReadWrapper(itemAggregateReadFunc),
-		Update: itemGetUpdateWrapper(itemAggregateModFunc, itemAggregateReadFunc),
-		Delete: resourceItemDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+	return stateVersioned(&schema.Resource{
+		Create:        itemGetCreateWrapper(itemAggregateModFunc, itemAggregateReadFunc),
+		Read:          itemGetReadWrapper(itemAggregateReadFunc),
+		Update:        itemGetUpdateWrapper(itemAggregateModFunc, itemAggregateReadFunc),
+		Delete:        resourceItemDelete,
+		CustomizeDiff: itemReferenceCheck,
+		Importer:      itemImporter(),
 
 		Schema: mergeSchemas(itemCommonSchema, itemDelaySchema),
-	}
+	}, nil)
 }
 
 // Custom mod handler for item type
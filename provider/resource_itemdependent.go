@@ -1,6 +1,9 @@
 package provider
 
 import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/tpretz/go-zabbix-api"
@@ -8,24 +11,37 @@ import (
 
 // resourceItemDependent terraform resource for agent items
 func resourceItemDependent() *schema.Resource {
-	return &schema.Resource{
-		Create: itemGetCreateWrapper(itemDependentModFunc, itemDependentReadFunc),
-		Read:   itemGetReadWrapper(itemDependentReadFunc),
-		Update: itemGetUpdateWrapper(itemDependentModFunc, itemDependentReadFunc),
+	return stateVersioned(&schema.Resource{
+		Create: itemDependentMasterWrapper(itemGetCreateWrapper(itemDependentModFunc, itemDependentReadFunc)),
+		Read:   itemDependentMasterWrapper(itemGetReadWrapper(itemDependentReadFunc)),
+		Update: itemDependentMasterWrapper(itemGetUpdateWrapper(itemDependentModFunc, itemDependentReadFunc)),
 		Delete: resourceItemDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		CustomizeDiff: customdiff.All(
+			itemHostDiff,
+			itemDependentMasterDiff,
+			referenceCheck(map[string]string{
+				"hostid":        "host",
+				"applications":  "application",
+				"master_itemid": "item",
+			}),
+		),
+		Importer: itemImporter(),
 
 		Schema: mergeSchemas(itemCommonSchema, map[string]*schema.Schema{
+			// changed in place, replaced only with a deleted master, see itemDependentMasterDiff
 			"master_itemid": &schema.Schema{
 				Type:         schema.TypeString,
-				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
 				Description:  "Master Item ID",
 				Required:     true,
 			},
+			"master_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Key of the master item",
+				Computed:    true,
+			},
 		}),
-	}
+	}, nil)
 }
 
 func itemDependentModFunc(d *schema.ResourceData, item *zabbix.Item) {
@@ -37,3 +53,59 @@ func itemDependentModFunc(d *schema.ResourceData, item *zabbix.Item) {
 func itemDependentReadFunc(d *schema.ResourceData, item *zabbix.Item) {
 	d.Set("master_itemid", item.MasterItemID)
 }
+
+// itemDependentMasterDiff replace the item when its master is gone, zabbix deleted the item with it
+func itemDependentMasterDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("master_itemid") {
+		return nil
+	}
+
+	// a master created in this apply replaces the old one, deleting it deletes this item too
+	if !d.NewValueKnown("master_itemid") {
+		return d.ForceNew("master_itemid")
+	}
+
+	old, _ := d.GetChange("master_itemid")
+	_, found, err := itemDependentMasterKey(m.(*providerMeta), old.(string))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return d.ForceNew("master_itemid")
+	}
+	return nil
+}
+
+// itemDependentMasterWrapper also read the master key after a create, read or update
+func itemDependentMasterWrapper(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, m interface{}) error {
+		if err := f(d, m); err != nil || d.Id() == "" {
+			return err
+		}
+
+		key, _, err := itemDependentMasterKey(m.(*providerMeta), d.Get("master_itemid").(string))
+		if err != nil {
+			return err
+		}
+		d.Set("master_key", key)
+		return nil
+	}
+}
+
+// itemDependentMasterKey key of a master item, found is false if it does not exist
+func itemDependentMasterKey(meta *providerMeta, id string) (key string, found bool, err error) {
+	if id == "" {
+		return "", false, nil
+	}
+
+	var items []map[string]interface{}
+	err = apiGet(meta.API, "item.get", zabbix.Params{
+		"itemids": []string{id},
+		"output":  []string{"itemid", "key_"},
+	}, &items)
+	if err != nil || len(items) < 1 {
+		return "", false, err
+	}
+	key, _ = items[0]["key_"].(string)
+	return key, true, nil
+}
@@ -40,14 +40,13 @@ var _ = func() bool {
 
 // resourceItemHttp Http item resource handler
 func resourceItemHttp() *schema.Resource {
-	return &schema.Resource{
-		Create: itemGetCreateWrapper(itemHttpModFunc, itemHttpReadFunc),
-		Read:   itemGetReadWrapper(itemHttpReadFunc),
-		Update: itemGetUpdateWrapper(itemHttpModFunc, itemHttpReadFunc),
-		Delete: resourceItemDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+	return stateVersioned(&schema.Resource{
+		Create:        itemGetCreateWrapper(itemHttpModFunc, itemHttpReadFunc),
+		Read:          itemGetReadWrapper(itemHttpReadFunc),
+		Update:        itemGetUpdateWrapper(itemHttpModFunc, itemHttpReadFunc),
+		Delete:        resourceItemDelete,
+		CustomizeDiff: itemReferenceCheck,
+		Importer:      itemImporter(),
 
 		Schema: mergeSchemas(itemCommonSchema, itemDelaySchema, itemInterfaceSchema, map[string]*schema.Schema{
 			"url": &schema.Schema{
@@ -82,10 +81,11 @@ func resourceItemHttp() *schema.Resource {
 				Description: "http status code",
 			},
 			"timeout": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "http request timeout",
-				Default:     "3s",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "http request timeout",
+				Default:          "3s",
+				DiffSuppressFunc: intervalDiffSuppress,
 			},
 			"verify_host": &schema.Schema{
 				Type:        schema.TypeBool,
@@ -100,7 +100,7 @@ func resourceItemHttp() *schema.Resource {
 				Default:     true,
 			},
 		}),
-	}
+	}, nil)
 }
 
 // http item modify custom function
@@ -124,7 +124,6 @@ func itemHttpModFunc(d *schema.ResourceData, item *zabbix.Item) {
 	if d.Get("verify_peer").(bool) {
 		item.VerifyPeer = "1"
 	}
-	item.ApplicationIds = buildApplicationIds(d.Get("applications").(*schema.Set))
 }
 
 // http item read custom function
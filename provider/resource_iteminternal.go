@@ -7,17 +7,16 @@ import (
 
 // terraform resource handler for item type
 func resourceItemInternal() *schema.Resource {
-	return &schema.Resource{
-		Create: itemGetCreateWrapper(itemInternalModFunc, itemInternalReadFunc),
-		Read:   itemGetReadWrapper(itemInternalReadFunc),
-		Update: itemGetUpdateWrapper(itemInternalModFunc, itemInternalReadFunc),
-		Delete: resourceItemDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+	return stateVersioned(&schema.Resource{
+		Create:        itemGetCreateWrapper(itemInternalModFunc, itemInternalReadFunc),
+		Read:          itemGetReadWrapper(itemInternalReadFunc),
+		Update:        itemGetUpdateWrapper(itemInternalModFunc, itemInternalReadFunc),
+		Delete:        resourceItemDelete,
+		CustomizeDiff: itemReferenceCheck,
+		Importer:      itemImporter(),
 
 		Schema: mergeSchemas(itemCommonSchema, itemDelaySchema, itemInterfaceSchema),
-	}
+	}, nil)
 }
 
 // Custom mod handler for item type
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform/helper/hashcode"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// dataItems terraform data source returning all matching items, of any type
+func dataItems() *schema.Resource {
+	return &schema.Resource{
+		Read: dataItemsRead,
+
+		Schema: map[string]*schema.Schema{
+			"hostids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Description: "Only return items on these hosts or templates",
+			},
+			"groupids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Description: "Only return items on hosts in these hostgroups",
+			},
+			"key": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Only return items with a key matching, * is a wildcard",
+			},
+			"tag": tagSetSchema,
+			"items": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"itemid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hostid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"valuetype": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Matching items",
+			},
+		},
+	}
+}
+
+// dataItemsRead read handler for data resource
+func dataItemsRead(d *schema.ResourceData, m interface{}) error {
+	meta := m.(*providerMeta)
+
+	params := zabbix.Params{
+		"output":    []string{"itemid", "hostid", "key_", "name", "value_type"},
+		"sortfield": "key_",
+	}
+
+	if v := d.Get("hostids").(*schema.Set); v.Len() > 0 {
+		params["hostids"] = v.List()
+	}
+	if v := d.Get("groupids").(*schema.Set); v.Len() > 0 {
+		params["groupids"] = v.List()
+	}
+	if params["hostids"] == nil && params["groupids"] == nil {
+		return errors.New("hostids or groupids are required")
+	}
+
+	if v, ok := d.GetOk("key"); ok {
+		params["search"] = map[string]interface{}{
+			"key_": v,
+		}
+		params["searchWildcardsEnabled"] = true
+	}
+	if tags := tagGenerate(d); len(tags) > 0 {
+		if !meta.Version.AtLeast(5, 4) {
+			return fmt.Errorf("item tags require zabbix 5.4 or later, server is %s", meta.Version)
+		}
+		params["tags"] = tagFilterGenerate(tags)
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	items, err := meta.API.ItemsGet(params)
+	if err != nil {
+		return err
+	}
+
+	list := make([]interface{}, len(items))
+	for i, item := range items {
+		list[i] = map[string]interface{}{
+			"itemid":    item.ItemID,
+			"hostid":    item.HostID,
+			"key":       item.Key,
+			"name":      item.Name,
+			"valuetype": ITEM_VALUE_TYPES_REV[item.ValueType],
+		}
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%v", params))))
+	d.Set("items", list)
+
+	return nil
+}
@@ -7,17 +7,16 @@ import (
 
 // terraform resource handler for item type
 func resourceItemSimple() *schema.Resource {
-	return &schema.Resource{
-		Create: itemGetCreateWrapper(itemSimpleModFunc, itemSimpleReadFunc),
-		Read:   itemGetReadWrapper(itemSimpleReadFunc),
-		Update: itemGetUpdateWrapper(itemSimpleModFunc, itemSimpleReadFunc),
-		Delete: resourceItemDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+	return stateVersioned(&schema.Resource{
+		Create:        itemGetCreateWrapper(itemSimpleModFunc, itemSimpleReadFunc),
+		Read:          itemGetReadWrapper(itemSimpleReadFunc),
+		Update:        itemGetUpdateWrapper(itemSimpleModFunc, itemSimpleReadFunc),
+		Delete:        resourceItemDelete,
+		CustomizeDiff: itemReferenceCheck,
+		Importer:      itemImporter(),
 
 		Schema: mergeSchemas(itemCommonSchema, itemDelaySchema),
-	}
+	}, nil)
 }
 
 // Custom mod handler for item type
@@ -61,14 +61,13 @@ var _ = func() bool {
 
 // terraform resource handler for item type
 func resourceItemSnmp() *schema.Resource {
-	return &schema.Resource{
-		Create: itemGetCreateWrapper(itemSnmpModFunc, itemSnmpReadFunc),
-		Read:   itemGetReadWrapper(itemSnmpReadFunc),
-		Update: itemGetUpdateWrapper(itemSnmpModFunc, itemSnmpReadFunc),
-		Delete: resourceItemDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+	return stateVersioned(&schema.Resource{
+		Create:        itemGetCreateWrapper(itemSnmpModFunc, itemSnmpReadFunc),
+		Read:          itemGetReadWrapper(itemSnmpReadFunc),
+		Update:        itemGetUpdateWrapper(itemSnmpModFunc, itemSnmpReadFunc),
+		Delete:        resourceItemDelete,
+		CustomizeDiff: itemReferenceCheck,
+		Importer:      itemImporter(),
 
 		Schema: mergeSchemas(itemCommonSchema, itemDelaySchema, itemInterfaceSchema, map[string]*schema.Schema{
 			"snmp_version": &schema.Schema{
@@ -87,6 +86,7 @@ func resourceItemSnmp() *schema.Resource {
 			"snmp_community": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
+				Sensitive:    true,
 				Description:  "SNMP Community (v1/v2 only)",
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 				Default:      "{$SNMP_COMMUNITY}",
@@ -94,6 +94,7 @@ func resourceItemSnmp() *schema.Resource {
 			"snmp3_authpassphrase": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
+				Sensitive:    true,
 				Description:  "Authentication Passphrase (v3 only)",
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 				Default:      "{$SNMP3_AUTHPASSPHRASE}",
@@ -115,6 +116,7 @@ func resourceItemSnmp() *schema.Resource {
 			"snmp3_privpassphrase": &schema.Schema{
 				Type:         schema.TypeString,
 				Optional:     true,
+				Sensitive:    true,
 				Description:  "Priv Passphrase (v3 only)",
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 				Default:      "{$SNMP3_PRIVPASSPHRASE}",
@@ -141,7 +143,7 @@ func resourceItemSnmp() *schema.Resource {
 				Default:      "{$SNMP3_SECURITYNAME}",
 			},
 		}),
-	}
+	}, nil)
 }
 
 // Custom mod handler for item type
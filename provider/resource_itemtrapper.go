@@ -7,17 +7,16 @@ import (
 
 // terraform resource handler for item type
 func resourceItemTrapper() *schema.Resource {
-	return &schema.Resource{
-		Create: itemGetCreateWrapper(itemTrapperModFunc, itemTrapperReadFunc),
-		Read:   itemGetReadWrapper(itemTrapperReadFunc),
-		Update: itemGetUpdateWrapper(itemTrapperModFunc, itemTrapperReadFunc),
-		Delete: resourceItemDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+	return stateVersioned(&schema.Resource{
+		Create:        itemGetCreateWrapper(itemTrapperModFunc, itemTrapperReadFunc),
+		Read:          itemGetReadWrapper(itemTrapperReadFunc),
+		Update:        itemGetUpdateWrapper(itemTrapperModFunc, itemTrapperReadFunc),
+		Delete:        resourceItemDelete,
+		CustomizeDiff: itemReferenceCheck,
+		Importer:      itemImporter(),
 
 		Schema: itemCommonSchema,
-	}
+	}, nil)
 }
 
 // Custom mod handler for item type
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// lldRuleObject zabbix discovery rule, not modelled by the library
+type lldRuleObject struct {
+	ItemID string `json:"itemid,omitempty"`
+	HostID string `json:"hostid"`
+	Key    string `json:"key_"`
+	Name   string `json:"name"`
+	Delay  string `json:"delay"`
+	Status string `json:"status,omitempty"`
+}
+
+// dataLLDRule terraform discovery rule data source entrypoint
+func dataLLDRule() *schema.Resource {
+	return &schema.Resource{
+		Read: dataLLDRuleRead,
+
+		Schema: map[string]*schema.Schema{
+			"itemid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Discovery rule ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"hostid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Host or template ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"key": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Discovery rule KEY",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Discovery rule name",
+			},
+			"delay": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Discovery rule delay period",
+			},
+			"enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Discovery rule enabled",
+			},
+		},
+	}
+}
+
+// dataLLDRuleRead read handler for data resource
+func dataLLDRuleRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"output": []string{"itemid", "hostid", "key_", "name", "delay", "status"},
+		"filter": map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("itemid"); ok {
+		params["itemids"] = v
+	} else {
+		hostid, hok := d.GetOk("hostid")
+		key, kok := d.GetOk("key")
+		if !hok || !kok {
+			return errors.New("itemid, or both hostid and key, are required")
+		}
+		params["hostids"] = hostid
+		params["filter"].(map[string]interface{})["key_"] = key
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var rules []lldRuleObject
+	if err := api.CallWithErrorParse("discoveryrule.get", params, &rules); err != nil {
+		return err
+	}
+
+	if len(rules) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(rules) > 1 {
+		return errors.New("multiple discovery rules found")
+	}
+	rule := rules[0]
+
+	log.Debug("Got discovery rule: %+v", rule)
+
+	d.SetId(rule.ItemID)
+	d.Set("itemid", rule.ItemID)
+	d.Set("hostid", rule.HostID)
+	d.Set("key", rule.Key)
+	d.Set("name", rule.Name)
+	d.Set("delay", rule.Delay)
+	d.Set("enabled", rule.Status == "0")
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// media type lookup tables
+var MEDIATYPE_TYPES = map[string]string{
+	"email":      "0",
+	"script":     "1",
+	"sms":        "2",
+	"jabber":     "3",
+	"webhook":    "4",
+	"ez_texting": "100",
+}
+var MEDIATYPE_TYPES_REV = map[string]string{}
+
+// generate the above structures
+var _ = func() bool {
+	for k, v := range MEDIATYPE_TYPES {
+		MEDIATYPE_TYPES_REV[v] = k
+	}
+	return false
+}()
+
+// mediatypeObject zabbix media type, not modelled by the library
+type mediatypeObject struct {
+	MediatypeID string `json:"mediatypeid,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+// mediatypeNameField filter field holding the media type name, renamed in zabbix 4.4
+func mediatypeNameField(api *zabbix.API) (string, error) {
+	version, err := apiVersion(api)
+	if err != nil {
+		return "", err
+	}
+	if version.AtLeast(4, 4) {
+		return "name", nil
+	}
+	return "description", nil
+}
+
+// dataMediatype terraform media type data source entrypoint
+func dataMediatype() *schema.Resource {
+	return &schema.Resource{
+		Read: dataMediatypeRead,
+
+		Schema: map[string]*schema.Schema{
+			"mediatypeid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Media type ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Media type name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"type": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Media type transport, one of: email, script, sms, jabber, webhook, ez_texting",
+			},
+			"enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Media type enabled",
+			},
+		},
+	}
+}
+
+// dataMediatypeRead read handler for data resource
+func dataMediatypeRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"output": []string{"mediatypeid", "name", "description", "type", "status"},
+		"filter": map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("mediatypeid"); ok {
+		params["mediatypeids"] = v
+	}
+	if v, ok := d.GetOk("name"); ok {
+		field, err := mediatypeNameField(api)
+		if err != nil {
+			return err
+		}
+		params["filter"].(map[string]interface{})[field] = v
+	}
+
+	if params["mediatypeids"] == nil && len(params["filter"].(map[string]interface{})) < 1 {
+		return errors.New("no media type lookup attribute")
+	}
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var mediatypes []mediatypeObject
+	if err := apiGet(api, "mediatype.get", params, &mediatypes); err != nil {
+		return err
+	}
+
+	if len(mediatypes) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(mediatypes) > 1 {
+		return errors.New("multiple media types found")
+	}
+	mediatype := mediatypes[0]
+
+	log.Debug("Got media type: %+v", mediatype)
+
+	// before 4.4 the name was held in description
+	name := mediatype.Name
+	if name == "" {
+		name = mediatype.Description
+	}
+
+	d.SetId(mediatype.MediatypeID)
+	d.Set("mediatypeid", mediatype.MediatypeID)
+	d.Set("name", name)
+	d.Set("type", MEDIATYPE_TYPES_REV[mediatype.Type])
+	d.Set("enabled", mediatype.Status == "0")
+
+	return nil
+}
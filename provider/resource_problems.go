@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform/helper/hashcode"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// problemObject zabbix problem, not modelled by the library
+type problemObject struct {
+	EventID      string              `json:"eventid"`
+	ObjectID     string              `json:"objectid"`
+	Name         string              `json:"name"`
+	Severity     zabbix.SeverityType `json:"severity,string"`
+	Acknowledged string              `json:"acknowledged"`
+	Clock        string              `json:"clock"`
+	Tags         zabbix.Tags         `json:"tags,omitempty"`
+}
+
+// dataProblems terraform data source returning current problems
+func dataProblems() *schema.Resource {
+	return &schema.Resource{
+		Read: dataProblemsRead,
+
+		Schema: map[string]*schema.Schema{
+			"hostids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Description: "Only return problems on these hosts",
+			},
+			"groupids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Description: "Only return problems on hosts in these hostgroups",
+			},
+			"severities": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(TRIGGER_PRIORITY_ARR, false),
+				},
+				Description: "Only return problems of these severities, any of: " + strings.Join(TRIGGER_PRIORITY_ARR, ", "),
+			},
+			"acknowledged": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return acknowledged (true) or unacknowledged (false) problems",
+			},
+			"tag": tagSetSchema,
+			"problems": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"eventid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"triggerid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"severity": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"acknowledged": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"clock": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"tag": &schema.Schema{
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"value": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+				Description: "Matching problems",
+			},
+		},
+	}
+}
+
+// dataProblemsRead read handler for data resource
+func dataProblemsRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"output":     []string{"eventid", "objectid", "name", "severity", "acknowledged", "clock"},
+		"selectTags": "extend",
+		"source":     0,
+		"object":     0,
+		"sortfield":  []string{"eventid"},
+		"sortorder":  "DESC",
+	}
+
+	if v := d.Get("hostids").(*schema.Set); v.Len() > 0 {
+		params["hostids"] = v.List()
+	}
+	if v := d.Get("groupids").(*schema.Set); v.Len() > 0 {
+		params["groupids"] = v.List()
+	}
+	if v := d.Get("severities").(*schema.Set); v.Len() > 0 {
+		severities := []zabbix.SeverityType{}
+		for _, s := range v.List() {
+			severities = append(severities, TRIGGER_PRIORITY[s.(string)])
+		}
+		params["severities"] = severities
+	}
+	if v, ok := d.GetOkExists("acknowledged"); ok {
+		params["acknowledged"] = v.(bool)
+	}
+	if tags := tagGenerate(d); len(tags) > 0 {
+		params["tags"] = tagFilterGenerate(tags)
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var problems []problemObject
+	if err := api.CallWithErrorParse("problem.get", params, &problems); err != nil {
+		return err
+	}
+
+	list := make([]interface{}, len(problems))
+	for i, p := range problems {
+		clock, _ := strconv.ParseInt(p.Clock, 10, 64)
+		list[i] = map[string]interface{}{
+			"eventid":      p.EventID,
+			"triggerid":    p.ObjectID,
+			"name":         p.Name,
+			"severity":     TRIGGER_PRIORITY_REV[p.Severity],
+			"acknowledged": p.Acknowledged == "1",
+			"clock":        clock,
+			"tag":          flattenTags(p.Tags),
+		}
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%v", params))))
+	d.Set("problems", list)
+
+	return nil
+}
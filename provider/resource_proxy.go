@@ -2,6 +2,7 @@ package provider
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -19,6 +20,32 @@ var proxySchemaBase = map[string]*schema.Schema{
 	},
 }
 
+// proxyObject library proxy struct, extended with the zabbix 7.0 name attribute
+type proxyObject struct {
+	zabbix.Proxy
+	Name string `json:"name,omitempty"`
+}
+
+// proxyHostname proxy name, regardless of api version
+func (p proxyObject) proxyHostname() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.Host
+}
+
+// proxyNameField filter field holding the proxy name, renamed in zabbix 7.0
+func proxyNameField(api *zabbix.API) (string, error) {
+	version, err := apiVersion(api)
+	if err != nil {
+		return "", err
+	}
+	if version.AtLeast(7, 0) {
+		return "name", nil
+	}
+	return "host", nil
+}
+
 // dataProxy terraform proxy resource entrypoint
 func dataProxy() *schema.Resource {
 	return &schema.Resource{
@@ -30,15 +57,16 @@ func dataProxy() *schema.Resource {
 // dataProxyRead read handler for data resource
 func dataProxyRead(d *schema.ResourceData, m interface{}) error {
 	params := zabbix.Params{
-		"selectInterface": "extend",
-		"filter":          map[string]interface{}{},
+		"filter": map[string]interface{}{},
 	}
 
-	lookups := []string{"host"}
-	for _, k := range lookups {
-		if v, ok := d.GetOk(k); ok {
-			params["filter"].(map[string]interface{})[k] = v
-		}
+	field, err := proxyNameField(m.(*providerMeta).API)
+	if err != nil {
+		return err
+	}
+
+	if v, ok := d.GetOk("host"); ok {
+		params["filter"].(map[string]interface{})[field] = v
 	}
 
 	if len(params["filter"].(map[string]interface{})) < 1 {
@@ -51,11 +79,11 @@ func dataProxyRead(d *schema.ResourceData, m interface{}) error {
 
 // proxyRead common proxy read function
 func proxyRead(d *schema.ResourceData, m interface{}, params zabbix.Params) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
 
 	log.Debug("Lookup of proxy with params %#v", params)
 
-	proxys, err := api.ProxiesGet(params)
+	proxys, err := proxiesGet(api, params)
 
 	if err != nil {
 		return err
@@ -73,7 +101,61 @@ func proxyRead(d *schema.ResourceData, m interface{}, params zabbix.Params) erro
 	log.Debug("Got proxy: %+v", proxy)
 
 	d.SetId(proxy.ProxyID)
-	d.Set("host", proxy.Host)
+	d.Set("host", proxy.proxyHostname())
 
 	return nil
 }
+
+// proxiesGet fetch proxies, including attributes not modelled by the library
+func proxiesGet(api *zabbix.API, params zabbix.Params) (proxys []proxyObject, err error) {
+	if _, ok := params["output"]; !ok {
+		params["output"] = "extend"
+	}
+	err = apiGet(api, "proxy.get", params, &proxys)
+	return
+}
+
+// proxyIdLookup resolve a proxy name to its id
+func proxyIdLookup(api *zabbix.API, name string) (string, error) {
+	field, err := proxyNameField(api)
+	if err != nil {
+		return "", err
+	}
+
+	proxys, err := proxiesGet(api, zabbix.Params{
+		"filter": map[string]interface{}{
+			field: name,
+		},
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(proxys) != 1 {
+		return "", fmt.Errorf("expected one proxy named %s, found %d", name, len(proxys))
+	}
+
+	return proxys[0].ProxyID, nil
+}
+
+// proxyNameLookup resolve a proxy id to its name, no proxy resolves to an empty name
+func proxyNameLookup(api *zabbix.API, id string) (string, error) {
+	if id == "" || id == "0" {
+		return "", nil
+	}
+
+	proxys, err := proxiesGet(api, zabbix.Params{
+		"proxyids": id,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(proxys) != 1 {
+		return "", fmt.Errorf("expected one proxy with id %s, found %d", id, len(proxys))
+	}
+
+	return proxys[0].proxyHostname(), nil
+}
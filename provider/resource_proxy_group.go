@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// proxyGroupObject zabbix 7.0 proxy group, not modelled by the library
+type proxyGroupObject struct {
+	ProxyGroupID  string `json:"proxy_groupid,omitempty"`
+	Name          string `json:"name"`
+	FailoverDelay string `json:"failover_delay,omitempty"`
+	MinOnline     string `json:"min_online,omitempty"`
+	Proxies       []struct {
+		ProxyID string `json:"proxyid"`
+	} `json:"proxies,omitempty"`
+}
+
+// dataProxyGroup terraform proxy group data source entrypoint
+func dataProxyGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataProxyGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"proxy_groupid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Proxy group ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Proxy group name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"failover_delay": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Period before hosts are moved off an offline proxy",
+			},
+			"min_online": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Minimum number of online proxies for the group to be online",
+			},
+			"proxyids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the proxies in the group",
+			},
+		},
+	}
+}
+
+// dataProxyGroupRead read handler for data resource
+func dataProxyGroupRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	version, err := apiVersion(api)
+	if err != nil {
+		return err
+	}
+	if !version.AtLeast(7, 0) {
+		return fmt.Errorf("proxy groups require zabbix 7.0 or later, server is %s", version)
+	}
+
+	params := zabbix.Params{
+		"output":        "extend",
+		"selectProxies": []string{"proxyid"},
+		"filter":        map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("proxy_groupid"); ok {
+		params["proxy_groupids"] = v
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params["filter"].(map[string]interface{})["name"] = v
+	}
+
+	if params["proxy_groupids"] == nil && len(params["filter"].(map[string]interface{})) < 1 {
+		return errors.New("no proxy group lookup attribute")
+	}
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var groups []proxyGroupObject
+	if err := api.CallWithErrorParse("proxygroup.get", params, &groups); err != nil {
+		return err
+	}
+
+	if len(groups) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(groups) > 1 {
+		return errors.New("multiple proxy groups found")
+	}
+	group := groups[0]
+
+	log.Debug("Got proxy group: %+v", group)
+
+	proxySet := schema.NewSet(schema.HashString, []interface{}{})
+	for _, v := range group.Proxies {
+		proxySet.Add(v.ProxyID)
+	}
+
+	d.SetId(group.ProxyGroupID)
+	d.Set("proxy_groupid", group.ProxyGroupID)
+	d.Set("name", group.Name)
+	d.Set("failover_delay", group.FailoverDelay)
+	d.Set("min_online", group.MinOnline)
+	d.Set("proxyids", proxySet)
+
+	return nil
+}
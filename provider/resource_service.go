@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// serviceObject zabbix business service, not modelled by the library
+type serviceObject struct {
+	ServiceID string      `json:"serviceid,omitempty"`
+	Name      string      `json:"name"`
+	Status    string      `json:"status,omitempty"`
+	Tags      zabbix.Tags `json:"tags,omitempty"`
+}
+
+// dataService terraform service data source entrypoint
+func dataService() *schema.Resource {
+	return &schema.Resource{
+		Read: dataServiceRead,
+
+		Schema: map[string]*schema.Schema{
+			"serviceid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Service ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Service name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Service status, -1 for OK or the severity of the problem",
+			},
+			"tag": tagSetDataSchema,
+		},
+	}
+}
+
+// dataServiceRead read handler for data resource
+func dataServiceRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	version, err := apiVersion(api)
+	if err != nil {
+		return err
+	}
+
+	params := zabbix.Params{
+		"output": "extend",
+		"filter": map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("serviceid"); ok {
+		params["serviceids"] = v
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params["filter"].(map[string]interface{})["name"] = v
+	}
+
+	// services only gained tags in 6.0
+	tags := tagGenerate(d)
+	if version.AtLeast(6, 0) {
+		params["selectTags"] = "extend"
+		if len(tags) > 0 {
+			params["tags"] = tagFilterGenerate(tags)
+		}
+	} else if len(tags) > 0 {
+		return errors.New("service tags require zabbix 6.0 or later")
+	}
+
+	if params["serviceids"] == nil && params["tags"] == nil && len(params["filter"].(map[string]interface{})) < 1 {
+		return errors.New("no service lookup attribute")
+	}
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var services []serviceObject
+	if err := api.CallWithErrorParse("service.get", params, &services); err != nil {
+		return err
+	}
+
+	if len(services) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(services) > 1 {
+		return errors.New("multiple services found")
+	}
+	service := services[0]
+
+	log.Debug("Got service: %+v", service)
+
+	d.SetId(service.ServiceID)
+	d.Set("serviceid", service.ServiceID)
+	d.Set("name", service.Name)
+	status, _ := strconv.Atoi(service.Status)
+	d.Set("status", status)
+	d.Set("tag", flattenTags(service.Tags))
+
+	return nil
+}
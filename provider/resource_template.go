@@ -2,13 +2,27 @@ package provider
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/tpretz/go-zabbix-api"
 )
 
+// templateObject library template struct, extended with attributes it does not model
+type templateObject struct {
+	zabbix.Template
+	UserMacros       macroObjects       `json:"macros,omitempty"`
+	LinkedTemplates  zabbix.TemplateIDs `json:"templates"`
+	TemplateIDsClear zabbix.TemplateIDs `json:"templates_clear,omitempty"`
+	ParentTemplates  zabbix.TemplateIDs `json:"parentTemplates,omitempty"`
+	Tags             *zabbix.Tags       `json:"tags,omitempty"`
+	VendorName       *string            `json:"vendor_name,omitempty"`
+	VendorVersion    *string            `json:"vendor_version,omitempty"`
+}
+
 // template resource function
 func resourceTemplate() *schema.Resource {
 	return &schema.Resource{
@@ -16,11 +30,16 @@ func resourceTemplate() *schema.Resource {
 		Read:   resourceTemplateRead,
 		Update: resourceTemplateUpdate,
 		Delete: resourceTemplateDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
-
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: customdiff.All(
+			referenceCheck(map[string]string{
+				"groups":    "templategroup",
+				"templates": "template",
+			}),
+			purgeCustomizeDiff,
+		),
+		Importer: importByName("template.get", "templateid", []string{"host", "name"}),
+
+		Schema: mergeSchemas(purgeSchema, map[string]*schema.Schema{
 			"groups": &schema.Schema{
 				Type: schema.TypeSet,
 				Elem: &schema.Schema{
@@ -46,8 +65,40 @@ func resourceTemplate() *schema.Resource {
 				Optional:    true,
 				Description: "Template Display Name (defaults to host)",
 			},
+			"templates": &schema.Schema{
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Optional:    true,
+				Description: "Linked Template IDs",
+			},
+			"templates_clear_on_unlink": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Clear items and triggers inherited from templates when unlinking them",
+			},
+			"deletion_protection": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail on destroy, deleting a template drops the history of items it created",
+			},
+			"vendor_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template vendor name (zabbix 6.4+)",
+			},
+			"vendor_version": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template vendor version (zabbix 6.4+)",
+			},
+			"tag":   tagSetSchema,
 			"macro": macroListSchema,
-		},
+		}),
 	}
 }
 
@@ -55,7 +106,7 @@ func dataTemplate() *schema.Resource {
 	return &schema.Resource{
 		Read: dataTemplateRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: mergeSchemas(searchSchema, map[string]*schema.Schema{
 			"groups": &schema.Schema{
 				Type: schema.TypeSet,
 				Elem: &schema.Schema{
@@ -81,19 +132,45 @@ func dataTemplate() *schema.Resource {
 				Computed:    true,
 				Description: "Template Display Name (defaults to host)",
 			},
+			"templates": &schema.Schema{
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "Linked Template IDs",
+			},
+			"vendor_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Template vendor name (zabbix 6.4+)",
+			},
+			"vendor_version": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Template vendor version (zabbix 6.4+)",
+			},
+			"tag":   tagSetComputedSchema,
 			"macro": macroListSchema,
-		},
+		}),
 	}
 }
 
 // terraform resource create handler
 func resourceTemplateCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
+
+	item, err := buildTemplateObject(d, api)
+
+	if err != nil {
+		return err
+	}
 
-	item := buildTemplateObject(d)
-	items := []zabbix.Template{*item}
+	items := []templateObject{*item}
 
-	err := api.TemplatesCreate(items)
+	err = apiRetry(m, func() error {
+		return templatesCreate(api, items)
+	})
 
 	if err != nil {
 		return err
@@ -110,8 +187,10 @@ func resourceTemplateCreate(d *schema.ResourceData, m interface{}) error {
 func dataTemplateRead(d *schema.ResourceData, m interface{}) error {
 
 	params := zabbix.Params{
-		"filter":       map[string]interface{}{},
-		"selectMacros": "extend",
+		"filter":                map[string]interface{}{},
+		"selectMacros":          "extend",
+		"selectParentTemplates": []string{"templateid"},
+		"selectTags":            "extend",
 	}
 
 	if v := d.Get("host").(string); v != "" {
@@ -122,9 +201,10 @@ func dataTemplateRead(d *schema.ResourceData, m interface{}) error {
 		params["filter"].(map[string]interface{})["name"] = v
 	}
 
-	if len(params["filter"].(map[string]interface{})) < 1 {
+	if searchEmpty(d, params) {
 		return errors.New("no filter parameters provided")
 	}
+	searchParams(d, params, "templateid")
 	log.Debug("Lookup of template with: %#v", params)
 
 	return templateRead(d, m, params)
@@ -134,17 +214,24 @@ func dataTemplateRead(d *schema.ResourceData, m interface{}) error {
 func resourceTemplateRead(d *schema.ResourceData, m interface{}) error {
 	log.Debug("Lookup of template with id %s", d.Id())
 
-	return templateRead(d, m, zabbix.Params{
-		"templateids":  d.Id(),
-		"selectMacros": "extend",
+	err := templateRead(d, m, zabbix.Params{
+		"templateids":           d.Id(),
+		"selectMacros":          "extend",
+		"selectParentTemplates": []string{"templateid"},
+		"selectTags":            "extend",
 	})
+	if err != nil || d.Id() == "" {
+		return err
+	}
+
+	return purgeRead(d, m)
 }
 
 // generic template read function
 func templateRead(d *schema.ResourceData, m interface{}, params zabbix.Params) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
 
-	templates, err := api.TemplatesGet(params)
+	templates, err := templatesGet(api, params)
 
 	if err != nil {
 		return err
@@ -164,45 +251,187 @@ func templateRead(d *schema.ResourceData, m interface{}, params zabbix.Params) e
 	d.Set("description", t.Description)
 	d.Set("host", t.Host)
 	d.Set("name", t.Name)
-	d.Set("macro", flattenMacros(t.UserMacros))
+	d.Set("macro", flattenMacros(t.UserMacros, d))
+
+	templateSet := schema.NewSet(schema.HashString, []interface{}{})
+	for _, v := range t.ParentTemplates {
+		templateSet.Add(v.TemplateID)
+	}
+	d.Set("templates", templateSet)
+
+	tags := zabbix.Tags{}
+	if t.Tags != nil {
+		tags = *t.Tags
+	}
+	d.Set("tag", flattenTags(tags))
+
+	if t.VendorName != nil {
+		d.Set("vendor_name", *t.VendorName)
+	}
+	if t.VendorVersion != nil {
+		d.Set("vendor_version", *t.VendorVersion)
+	}
 	d.SetId(t.TemplateID)
 
 	return nil
 }
 
 // build a template object from terraform data
-func buildTemplateObject(d *schema.ResourceData) *zabbix.Template {
-	item := zabbix.Template{
-		Description: d.Get("description").(string),
-		Name:        d.Get("name").(string),
-		Host:        d.Get("host").(string),
-		Groups:      buildHostGroupIds(d.Get("groups").(*schema.Set)),
+func buildTemplateObject(d *schema.ResourceData, api *zabbix.API) (*templateObject, error) {
+	item := templateObject{
+		Template: zabbix.Template{
+			Description: d.Get("description").(string),
+			Name:        d.Get("name").(string),
+			Host:        d.Get("host").(string),
+			Groups:      buildHostGroupIds(d.Get("groups").(*schema.Set)),
+		},
 	}
 
 	item.UserMacros = macroGenerate(d)
-	return &item
+	item.LinkedTemplates = buildTemplateIds(d.Get("templates").(*schema.Set))
+
+	// only send tags when managed, an empty list clears them
+	if tags := tagGenerate(d); len(tags) > 0 || d.HasChange("tag") {
+		item.Tags = &tags
+	}
+
+	// vendor fields are rejected by servers older than 6.4
+	vendorName := d.Get("vendor_name").(string)
+	vendorVersion := d.Get("vendor_version").(string)
+	if vendorName != "" || vendorVersion != "" || d.HasChange("vendor_name") || d.HasChange("vendor_version") {
+		version, err := apiVersion(api)
+		if err != nil {
+			return nil, err
+		}
+		if !version.AtLeast(6, 4) {
+			return nil, fmt.Errorf("vendor_name and vendor_version require zabbix 6.4 or newer, server is %s", version)
+		}
+		item.VendorName = &vendorName
+		item.VendorVersion = &vendorVersion
+	}
+
+	return &item, nil
 }
 
 // terraform update resource handler
 func resourceTemplateUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
+
+	item, err := buildTemplateObject(d, api)
+
+	if err != nil {
+		return err
+	}
 
-	item := buildTemplateObject(d)
 	item.TemplateID = d.Id()
 
-	items := []zabbix.Template{*item}
+	if d.Get("templates_clear_on_unlink").(bool) && d.HasChange("templates") {
+		o, n := d.GetChange("templates")
+		item.TemplateIDsClear = buildTemplateIds(o.(*schema.Set).Difference(n.(*schema.Set)))
+	}
+
+	items := []templateObject{*item}
 
-	err := api.TemplatesUpdate(items)
+	err = apiRetryLocked(m, hostLockKey(d.Id()), func() error {
+		return templatesUpdate(api, items)
+	})
 
 	if err != nil {
 		return err
 	}
 
+	if err := purgeApply(d, m); err != nil {
+		return err
+	}
+
 	return resourceTemplateRead(d, m)
 }
 
+// fetch templates, including attributes not modelled by the library
+func templatesGet(api *zabbix.API, params zabbix.Params) (templates []templateObject, err error) {
+	if _, ok := params["output"]; !ok {
+		params["output"] = "extend"
+	}
+	err = apiGet(api, "template.get", params, &templates)
+	return
+}
+
+// create templates, populating the generated template ids
+func templatesCreate(api *zabbix.API, templates []templateObject) error {
+	response, err := api.CallWithError("template.create", templates)
+
+	if err != nil {
+		return err
+	}
+
+	result := response.Result.(map[string]interface{})
+	templateids := result["templateids"].([]interface{})
+	for i, id := range templateids {
+		templates[i].TemplateID = id.(string)
+	}
+
+	return nil
+}
+
+// update templates
+func templatesUpdate(api *zabbix.API, templates []templateObject) error {
+	_, err := api.CallWithError("template.update", templates)
+	return err
+}
+
 // terraform delete handler
 func resourceTemplateDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
-	return api.TemplatesDeleteByIds([]string{d.Id()})
+	api := m.(*providerMeta).API
+
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("template %s has deletion_protection enabled, disable it and apply before destroying", d.Get("host"))
+	}
+	return apiRetryLocked(m, hostLockKey(d.Id()), func() error {
+		return api.TemplatesDeleteByIds([]string{d.Id()})
+	})
+}
+
+// templateIdsLookup resolve template technical names to ids with a single get
+func templateIdsLookup(api *zabbix.API, names []string) (zabbix.TemplateIDs, error) {
+	templates, err := templatesGet(api, zabbix.Params{
+		"output": []string{"templateid", "host"},
+		"filter": map[string]interface{}{"host": names},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]string{}
+	for _, v := range templates {
+		byName[v.Host] = v.TemplateID
+	}
+
+	ids := make(zabbix.TemplateIDs, len(names))
+	for i, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("template %s does not exist", name)
+		}
+		ids[i] = zabbix.TemplateID{TemplateID: id}
+	}
+
+	return ids, nil
+}
+
+// templateNamesLookup resolve template ids to technical names
+func templateNamesLookup(api *zabbix.API, ids []string) ([]interface{}, error) {
+	templates, err := templatesGet(api, zabbix.Params{
+		"output":      []string{"templateid", "host"},
+		"templateids": ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]interface{}, len(templates))
+	for i, v := range templates {
+		names[i] = v.Host
+	}
+
+	return names, nil
 }
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// dataTemplateExport terraform data handler for template exports
+func dataTemplateExport() *schema.Resource {
+	return &schema.Resource{
+		Read: dataTemplateExportRead,
+
+		Schema: map[string]*schema.Schema{
+			"templateids": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Description: "Template IDs to export",
+			},
+			"format": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "yaml",
+				Description:  "Export format, one of: xml, json, yaml",
+				ValidateFunc: validation.StringInSlice(TEMPLATE_IMPORT_FORMATS, false),
+			},
+			"content": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Exported configuration",
+			},
+		},
+	}
+}
+
+// dataTemplateExportRead terraform data read handler
+func dataTemplateExportRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	list := d.Get("templateids").([]interface{})
+	ids := make([]string, len(list))
+	for i := 0; i < len(list); i++ {
+		ids[i] = list[i].(string)
+	}
+
+	version, err := apiVersion(api)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("format").(string) == "yaml" && !version.AtLeast(5, 2) {
+		return fmt.Errorf("format yaml requires zabbix 5.2 or newer, server is %s, use xml or json", version)
+	}
+
+	params := zabbix.Params{
+		"format": d.Get("format").(string),
+		"options": map[string]interface{}{
+			"templates": ids,
+		},
+	}
+
+	log.Debug("exporting configuration with params: %#v", params)
+
+	response, err := api.CallWithError("configuration.export", params)
+
+	if err != nil {
+		return err
+	}
+
+	content, ok := response.Result.(string)
+	if !ok {
+		return errors.New("unexpected configuration export response")
+	}
+
+	d.SetId(templateImportHash(content))
+	d.Set("content", content)
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/tpretz/go-zabbix-api"
+)
+
+var TEMPLATE_IMPORT_FORMATS = []string{"xml", "json", "yaml"}
+
+// resourceTemplateImport terraform resource handler for configuration imports
+// any change re-runs the import, as content is only held in state as a hash
+func resourceTemplateImport() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTemplateImportCreate,
+		Read:   resourceTemplateImportRead,
+		Delete: resourceTemplateImportDelete,
+
+		Schema: map[string]*schema.Schema{
+			"content": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Zabbix export file content, only a hash is kept in state",
+				StateFunc:    templateImportHash,
+			},
+			"format": &schema.Schema{
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				Default:      "yaml",
+				Description:  "Content format, one of: xml, json, yaml",
+				ValidateFunc: validation.StringInSlice(TEMPLATE_IMPORT_FORMATS, false),
+			},
+			"create_missing": &schema.Schema{
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     true,
+				Description: "Create objects missing from the server",
+			},
+			"update_existing": &schema.Schema{
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     true,
+				Description: "Update objects already on the server",
+			},
+			"delete_missing": &schema.Schema{
+				Type:        schema.TypeBool,
+				ForceNew:    true,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete objects on the server that are missing from the content",
+			},
+		},
+	}
+}
+
+// templateImportHash hash content for state storage
+func templateImportHash(v interface{}) string {
+	sum := sha256.Sum256([]byte(v.(string)))
+	return hex.EncodeToString(sum[:])
+}
+
+// templateImportRules build import rules valid for the server version
+func templateImportRules(v serverVersion, create, update, del bool) map[string]interface{} {
+	cu := map[string]interface{}{"createMissing": create, "updateExisting": update}
+	cd := map[string]interface{}{"createMissing": create, "deleteMissing": del}
+	cud := map[string]interface{}{"createMissing": create, "updateExisting": update, "deleteMissing": del}
+
+	rules := map[string]interface{}{
+		"templates":      cu,
+		"items":          cud,
+		"discoveryRules": cud,
+		"triggers":       cud,
+		"graphs":         cud,
+		"httptests":      cud,
+	}
+
+	if v.AtLeast(6, 2) {
+		rules["template_groups"] = cu
+		rules["host_groups"] = cu
+	} else {
+		rules["groups"] = map[string]interface{}{"createMissing": create}
+	}
+
+	if v.AtLeast(5, 4) {
+		rules["templateLinkage"] = cd
+		rules["valueMaps"] = cud
+	} else {
+		rules["templateLinkage"] = map[string]interface{}{"createMissing": create}
+		rules["valueMaps"] = cu
+		rules["applications"] = cd
+	}
+
+	if v.AtLeast(5, 2) {
+		rules["templateDashboards"] = cud
+	} else {
+		rules["templateScreens"] = cud
+	}
+
+	return rules
+}
+
+// templateImport run a configuration import
+func templateImport(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	version, err := apiVersion(api)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("format").(string) == "yaml" && !version.AtLeast(5, 2) {
+		return fmt.Errorf("format yaml requires zabbix 5.2 or newer, server is %s, use xml or json", version)
+	}
+
+	params := zabbix.Params{
+		"format": d.Get("format").(string),
+		"source": d.Get("content").(string),
+		"rules": templateImportRules(
+			version,
+			d.Get("create_missing").(bool),
+			d.Get("update_existing").(bool),
+			d.Get("delete_missing").(bool),
+		),
+	}
+
+	log.Debug("importing configuration with rules: %#v", params["rules"])
+
+	return apiRetry(m, func() error {
+		_, err := api.CallWithError("configuration.import", params)
+		return err
+	})
+}
+
+// resourceTemplateImportCreate terraform create handler
+func resourceTemplateImportCreate(d *schema.ResourceData, m interface{}) error {
+	if err := templateImport(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(templateImportHash(d.Get("content")))
+
+	return resourceTemplateImportRead(d, m)
+}
+
+// resourceTemplateImportRead terraform read handler, imports can not be read back
+func resourceTemplateImportRead(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+// resourceTemplateImportDelete terraform delete handler, imported objects are left in place
+func resourceTemplateImportDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform/helper/hashcode"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// dataTemplates terraform data source returning all matching templates
+func dataTemplates() *schema.Resource {
+	return &schema.Resource{
+		Read: dataTemplatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"groupids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be a numeric string"),
+				},
+				Description: "Only return templates in these groups",
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Only return templates with a visible name matching, * is a wildcard",
+			},
+			"templates": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"templateid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Matching templates",
+			},
+		},
+	}
+}
+
+// dataTemplatesRead read handler for data resource
+func dataTemplatesRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"output":    []string{"templateid", "host", "name"},
+		"sortfield": "name",
+	}
+
+	if v := d.Get("groupids").(*schema.Set); v.Len() > 0 {
+		params["groupids"] = v.List()
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params["search"] = map[string]interface{}{
+			"name": v,
+		}
+		params["searchWildcardsEnabled"] = true
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	templates, err := templatesGet(api, params)
+	if err != nil {
+		return err
+	}
+
+	list := make([]interface{}, len(templates))
+	for i, t := range templates {
+		list[i] = map[string]interface{}{
+			"templateid": t.TemplateID,
+			"host":       t.Host,
+			"name":       t.Name,
+		}
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%v", params))))
+	d.Set("templates", list)
+
+	return nil
+}
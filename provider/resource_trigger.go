@@ -7,7 +7,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/tpretz/go-zabbix-api"
 )
 
@@ -19,6 +18,10 @@ var TRIGGER_PRIORITY = map[string]zabbix.SeverityType{
 	"high":           zabbix.High,
 	"disaster":       zabbix.Critical,
 }
+
+// placeholder replaced by the trigger host attribute
+const TRIGGER_HOST_PLACEHOLDER = "{HOST}"
+
 var TRIGGER_PRIORITY_REV = map[zabbix.SeverityType]string{}
 var TRIGGER_PRIORITY_ARR = []string{}
 
@@ -33,11 +36,14 @@ var _ = func() bool {
 
 // terraform resource handler for triggers
 func resourceTrigger() *schema.Resource {
-	return &schema.Resource{
+	return stateVersioned(&schema.Resource{
 		Create: resourceTriggerCreate,
 		Read:   resourceTriggerRead,
 		Update: resourceTriggerUpdate,
 		Delete: resourceTriggerDelete,
+		CustomizeDiff: referenceCheck(map[string]string{
+			"dependencies": "trigger",
+		}),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -55,6 +61,14 @@ func resourceTrigger() *schema.Resource {
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 				Description:  "Trigger Expression",
 				Required:     true,
+				// whitespace is not significant outside quoted strings
+				DiffSuppressFunc: expressionDiffSuppress,
+			},
+			"host": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Host technical name substituted for {HOST} in the expressions",
 			},
 			"comments": &schema.Schema{
 				Type:        schema.TypeString,
@@ -93,9 +107,10 @@ func resourceTrigger() *schema.Resource {
 				Description: "set recovery mode to none",
 			},
 			"recovery_expression": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "use recovery expression (recovery_none must not be true)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "use recovery expression (recovery_none must not be true)",
+				DiffSuppressFunc: expressionDiffSuppress,
 			},
 			"correlation_tag": &schema.Schema{
 				Type:        schema.TypeString,
@@ -117,65 +132,132 @@ func resourceTrigger() *schema.Resource {
 				},
 				Description: "Trigger Dependencies",
 			},
-			"tag": &schema.Schema{
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"key": &schema.Schema{
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringIsNotWhiteSpace,
-							Description:  "Tag Key",
-						},
-						"value": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Tag Value",
-						},
-					},
-				},
+			"tag": tagSetSchema,
+		},
+	}, nil)
+}
+
+// dataTrigger terraform trigger data source entrypoint
+func dataTrigger() *schema.Resource {
+	return &schema.Resource{
+		Read: dataTriggerRead,
+
+		Schema: map[string]*schema.Schema{
+			"triggerid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Trigger ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"hostid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Host or template ID the trigger belongs to",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Trigger name",
+			},
+			"expression": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Trigger Expression",
+			},
+			"priority": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Trigger Priority level",
+			},
+			"enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Enable trigger",
 			},
+			"tag": tagSetDataSchema,
 		},
 	}
 }
 
-// tagGenerate build tag structs from terraform inputs
-func tagGenerate(d *schema.ResourceData) (tags zabbix.Tags) {
-	set := d.Get("tag").(*schema.Set).List()
-	tags = make(zabbix.Tags, len(set))
+// dataTriggerRead read handler for data resource
+func dataTriggerRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"expandExpression": "extend",
+		"selectTags":       "extend",
+		"filter":           map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("triggerid"); ok {
+		params["triggerids"] = v
+	}
+	if v, ok := d.GetOk("hostid"); ok {
+		params["hostids"] = v
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params["filter"].(map[string]interface{})["description"] = v
+	}
+
+	if tags := tagGenerate(d); len(tags) > 0 {
+		params["tags"] = tagFilterGenerate(tags)
+	}
+
+	expression := d.Get("expression").(string)
+
+	if params["triggerids"] == nil && params["hostids"] == nil && params["tags"] == nil &&
+		len(params["filter"].(map[string]interface{})) < 1 && expression == "" {
+		return errors.New("no trigger lookup attribute")
+	}
+	log.Debug("performing data lookup with params: %#v", params)
 
-	for i := 0; i < len(set); i++ {
-		current := set[i].(map[string]interface{})
-		tags[i] = zabbix.Tag{
-			Tag:   current["key"].(string),
-			Value: current["value"].(string),
+	triggers, err := api.TriggersGet(params)
+	if err != nil {
+		return err
+	}
+
+	// the api filters on the unexpanded expression, match the expanded form here, ignoring whitespace
+	if expression != "" {
+		matched := zabbix.Triggers{}
+		for _, t := range triggers {
+			if expressionNormalize(t.Expression) == expressionNormalize(expression) {
+				matched = append(matched, t)
+			}
 		}
+		triggers = matched
 	}
 
-	return
-}
+	if len(triggers) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(triggers) > 1 {
+		return errors.New("multiple triggers found")
+	}
+	t := triggers[0]
+
+	log.Debug("Got trigger: %+v", t)
+
+	d.SetId(t.TriggerID)
+	d.Set("triggerid", t.TriggerID)
+	d.Set("name", t.Description)
+	d.Set("expression", t.Expression)
+	d.Set("priority", TRIGGER_PRIORITY_REV[t.Priority])
+	d.Set("enabled", t.Status == 0)
+	d.Set("tag", flattenTags(t.Tags))
 
-// flattenTags convert response to terraform input
-func flattenTags(list zabbix.Tags) *schema.Set {
-	set := schema.NewSet(func(i interface{}) int {
-		m := i.(map[string]interface{})
-		return hashcode.String(m["key"].(string) + "V" + m["value"].(string))
-	}, []interface{}{})
-	for i := 0; i < len(list); i++ {
-		set.Add(map[string]interface{}{
-			"key":   list[i].Tag,
-			"value": list[i].Value,
-		})
-	}
-	return set
+	return nil
 }
 
 // Build Trigger struct for create/modify
-func buildTriggerObject(d *schema.ResourceData) zabbix.Trigger {
+func buildTriggerObject(d *schema.ResourceData, meta *providerMeta) zabbix.Trigger {
 	item := zabbix.Trigger{
 		Description:        d.Get("name").(string),
-		Expression:         d.Get("expression").(string),
+		Expression:         triggerHostExpand(d.Get("expression").(string), d.Get("host").(string)),
 		Comments:           d.Get("comments").(string),
 		Priority:           TRIGGER_PRIORITY[d.Get("priority").(string)],
 		Status:             0,
@@ -199,7 +281,7 @@ func buildTriggerObject(d *schema.ResourceData) zabbix.Trigger {
 		item.RecoveryMode = "2"
 	} else if v := d.Get("recovery_expression").(string); v != "" {
 		item.RecoveryMode = "1"
-		item.RecoveryExpression = v
+		item.RecoveryExpression = triggerHostExpand(v, d.Get("host").(string))
 	}
 
 	if v := d.Get("correlation_tag").(string); v != "" {
@@ -212,20 +294,43 @@ func buildTriggerObject(d *schema.ResourceData) zabbix.Trigger {
 	}
 
 	item.Dependencies = buildTriggerIds(d.Get("dependencies").(*schema.Set))
-	item.Tags = tagGenerate(d)
+	item.Tags = tagsWithDefaults(tagGenerate(d), meta.DefaultTags)
 
 	return item
 }
 
+// triggerHostExpand replace the {HOST} placeholder with a host name
+func triggerHostExpand(expression, host string) string {
+	if host == "" {
+		return expression
+	}
+	return strings.ReplaceAll(expression, TRIGGER_HOST_PLACEHOLDER, host)
+}
+
+// triggerHostCollapse keep the configured expression, with its {HOST} placeholders, while the
+// expression read from the api is its expansion, host names written out are left as they are
+func triggerHostCollapse(expression, configured, host string) string {
+	if host == "" || !strings.Contains(configured, TRIGGER_HOST_PLACEHOLDER) {
+		return expression
+	}
+	if expressionNormalize(triggerHostExpand(configured, host)) == expressionNormalize(expression) {
+		return configured
+	}
+	return expression
+}
+
 // create trigger terraform handler
 func resourceTriggerCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	meta := m.(*providerMeta)
+	api := meta.API
 
-	item := buildTriggerObject(d)
+	item := buildTriggerObject(d, meta)
 
 	items := []zabbix.Trigger{item}
 
-	err := api.TriggersCreate(items)
+	err := apiRetryLocked(m, "trigger", func() error {
+		return api.TriggersCreate(items)
+	})
 
 	if err != nil {
 		return err
@@ -240,14 +345,14 @@ func resourceTriggerCreate(d *schema.ResourceData, m interface{}) error {
 
 // read tirgger terraform handler
 func resourceTriggerRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	api := m.(*providerMeta).API
 
 	log.Debug("Lookup of trigger with id %s", d.Id())
 
 	triggers, err := api.TriggersGet(zabbix.Params{
 		"triggerids":         d.Id(),
 		"expandExpression":   "extend",
-		"selectDependencies": "extend",
+		"selectDependencies": []string{"triggerid"},
 		"selectTags":         "extend",
 	})
 
@@ -267,16 +372,18 @@ func resourceTriggerRead(d *schema.ResourceData, m interface{}) error {
 	log.Debug("Got trigger: %+v", t)
 
 	d.Set("name", t.Description)
-	d.Set("expression", t.Expression)
+	host := d.Get("host").(string)
+	d.Set("expression", triggerHostCollapse(t.Expression, d.Get("expression").(string), host))
 	d.Set("comments", t.Comments)
 	d.Set("priority", TRIGGER_PRIORITY_REV[t.Priority])
 	d.Set("enabled", t.Status == 0)
 	d.Set("multiple", t.Type == "1")
 	d.Set("url", t.Url)
-	d.Set("recovery_expression", t.RecoveryExpression)
+	d.Set("recovery_expression", triggerHostCollapse(t.RecoveryExpression, d.Get("recovery_expression").(string), host))
 	d.Set("correlation_tag", t.CorrelationTag)
 	d.Set("manual_close", t.ManualClose == "1")
-	d.Set("tag", flattenTags(t.Tags))
+	// provider default tags are not part of the resource config
+	d.Set("tag", flattenTags(tagsWithoutDefaults(t.Tags, m.(*providerMeta).DefaultTags, tagGenerate(d))))
 
 	if t.RecoveryMode == "2" {
 		d.Set("recovery_none", true)
@@ -305,15 +412,22 @@ func resourceTriggerRead(d *schema.ResourceData, m interface{}) error {
 
 // update trigger terraform handler
 func resourceTriggerUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
+	meta := m.(*providerMeta)
+	api := meta.API
+
+	if err := objectEditable(api, "trigger", d.Id()); err != nil {
+		return err
+	}
 
-	item := buildTriggerObject(d)
+	item := buildTriggerObject(d, meta)
 
 	item.TriggerID = d.Id()
 
 	items := []zabbix.Trigger{item}
 
-	err := api.TriggersUpdate(items)
+	err := apiRetryLocked(m, "trigger", func() error {
+		return api.TriggersUpdate(items)
+	})
 
 	if err != nil {
 		return err
@@ -324,6 +438,12 @@ func resourceTriggerUpdate(d *schema.ResourceData, m interface{}) error {
 
 // delete trigger terraform handler
 func resourceTriggerDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*zabbix.API)
-	return api.TriggersDeleteByIds([]string{d.Id()})
+	api := m.(*providerMeta).API
+
+	if err := objectEditable(api, "trigger", d.Id()); err != nil {
+		return err
+	}
+	return apiRetryLocked(m, "trigger", func() error {
+		return api.TriggersDeleteByIds([]string{d.Id()})
+	})
 }
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var TRIGGER_EXPRESSION_OPERATORS = []string{"=", "<>", "<", "<=", ">", ">="}
+
+// dataTriggerExpression terraform data source assembling a trigger expression for the server version
+func dataTriggerExpression() *schema.Resource {
+	return &schema.Resource{
+		Read: dataTriggerExpressionRead,
+
+		Schema: map[string]*schema.Schema{
+			"host": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Technical name of the host or template",
+			},
+			"key": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Item key",
+			},
+			"function": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "last",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[a-z][a-z_.]*$"), "must be a zabbix function name"),
+				Description:  "Trigger function, e.g. last, avg, nodata",
+			},
+			"args": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Function arguments after the item, e.g. 5m",
+			},
+			"operator": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(TRIGGER_EXPRESSION_OPERATORS, false),
+				Description:  "Comparison operator, one of: " + strings.Join(TRIGGER_EXPRESSION_OPERATORS, ", "),
+			},
+			"threshold": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Value compared against, required with operator",
+			},
+			"expression": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Assembled trigger expression",
+			},
+		},
+	}
+}
+
+// triggerExpression assemble a single function expression, 5.4 replaced {host:key.func(args)} with func(/host/key,args)
+func triggerExpression(v serverVersion, host, key, function string, args []string) string {
+	if v.AtLeast(5, 4) {
+		params := append([]string{fmt.Sprintf("/%s/%s", host, key)}, args...)
+		return fmt.Sprintf("%s(%s)", function, strings.Join(params, ","))
+	}
+	return fmt.Sprintf("{%s:%s.%s(%s)}", host, key, function, strings.Join(args, ","))
+}
+
+// dataTriggerExpressionRead read handler for data resource
+func dataTriggerExpressionRead(d *schema.ResourceData, m interface{}) error {
+	meta := m.(*providerMeta)
+
+	list := d.Get("args").([]interface{})
+	args := make([]string, len(list))
+	for i := range list {
+		args[i], _ = list[i].(string)
+	}
+
+	expression := triggerExpression(meta.Version, d.Get("host").(string), d.Get("key").(string), d.Get("function").(string), args)
+
+	operator := d.Get("operator").(string)
+	threshold := d.Get("threshold").(string)
+	if (operator == "") != (threshold == "") {
+		return errors.New("operator and threshold must be set together")
+	}
+	expression += operator + threshold
+
+	d.SetId(expression)
+	d.Set("expression", expression)
+
+	return nil
+}
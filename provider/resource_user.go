@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// userObject zabbix user, not modelled by the library
+type userObject struct {
+	UserID     string            `json:"userid,omitempty"`
+	Alias      string            `json:"alias,omitempty"`
+	Username   string            `json:"username,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Surname    string            `json:"surname,omitempty"`
+	RoleID     string            `json:"roleid,omitempty"`
+	UserGroups []usergroupObject `json:"usrgrps,omitempty"`
+}
+
+// userLogin user login name, regardless of api version
+func (u userObject) userLogin() string {
+	if u.Username != "" {
+		return u.Username
+	}
+	return u.Alias
+}
+
+// userLoginField filter field holding the user login, renamed in zabbix 5.4
+func userLoginField(api *zabbix.API) (string, error) {
+	version, err := apiVersion(api)
+	if err != nil {
+		return "", err
+	}
+	if version.AtLeast(5, 4) {
+		return "username", nil
+	}
+	return "alias", nil
+}
+
+// dataUser terraform user data source entrypoint
+func dataUser() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUserRead,
+
+		Schema: map[string]*schema.Schema{
+			"userid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "User ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"username": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "User login name (alias before zabbix 5.4)",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "First name",
+			},
+			"surname": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last name",
+			},
+			"roleid": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "User role ID (zabbix 5.2+)",
+			},
+			"usergroups": &schema.Schema{
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the user groups the user belongs to",
+			},
+		},
+	}
+}
+
+// dataUserRead read handler for data resource
+func dataUserRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"selectUsrgrps": []string{"usrgrpid"},
+		"filter":        map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("userid"); ok {
+		params["userids"] = v
+	}
+	if v, ok := d.GetOk("username"); ok {
+		field, err := userLoginField(api)
+		if err != nil {
+			return err
+		}
+		params["filter"].(map[string]interface{})[field] = v
+	}
+
+	if params["userids"] == nil && len(params["filter"].(map[string]interface{})) < 1 {
+		return errors.New("no user lookup attribute")
+	}
+	log.Debug("performing data lookup with params: %#v", params)
+
+	users, err := usersGet(api, params)
+	if err != nil {
+		return err
+	}
+
+	if len(users) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(users) > 1 {
+		return errors.New("multiple users found")
+	}
+	user := users[0]
+
+	log.Debug("Got user: %+v", user)
+
+	d.SetId(user.UserID)
+	d.Set("userid", user.UserID)
+	d.Set("username", user.userLogin())
+	d.Set("name", user.Name)
+	d.Set("surname", user.Surname)
+	d.Set("roleid", user.RoleID)
+
+	groupSet := schema.NewSet(schema.HashString, []interface{}{})
+	for _, v := range user.UserGroups {
+		groupSet.Add(v.UserGroupID)
+	}
+	d.Set("usergroups", groupSet)
+
+	return nil
+}
+
+// usersGet fetch users
+func usersGet(api *zabbix.API, params zabbix.Params) (users []userObject, err error) {
+	if _, ok := params["output"]; !ok {
+		params["output"] = "extend"
+	}
+	err = apiGet(api, "user.get", params, &users)
+	return
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// user role lookup tables
+var USER_ROLE_TYPES_REV = map[string]string{
+	"1": "user",
+	"2": "admin",
+	"3": "super_admin",
+}
+
+// userRoleObject zabbix 5.2 user role, not modelled by the library
+type userRoleObject struct {
+	RoleID   string `json:"roleid,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"`
+	ReadOnly string `json:"readonly,omitempty"`
+}
+
+// dataUserRole terraform user role data source entrypoint
+func dataUserRole() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUserRoleRead,
+
+		Schema: map[string]*schema.Schema{
+			"roleid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "User role ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "User role name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"type": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "User type, one of: user, admin, super_admin",
+			},
+			"readonly": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Built in role that can not be changed",
+			},
+		},
+	}
+}
+
+// dataUserRoleRead read handler for data resource
+func dataUserRoleRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	version, err := apiVersion(api)
+	if err != nil {
+		return err
+	}
+	if !version.AtLeast(5, 2) {
+		return fmt.Errorf("user roles require zabbix 5.2 or later, server is %s", version)
+	}
+
+	params := zabbix.Params{
+		"output": "extend",
+		"filter": map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("roleid"); ok {
+		params["roleids"] = v
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params["filter"].(map[string]interface{})["name"] = v
+	}
+
+	if params["roleids"] == nil && len(params["filter"].(map[string]interface{})) < 1 {
+		return errors.New("no user role lookup attribute")
+	}
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var roles []userRoleObject
+	if err := api.CallWithErrorParse("role.get", params, &roles); err != nil {
+		return err
+	}
+
+	if len(roles) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(roles) > 1 {
+		return errors.New("multiple user roles found")
+	}
+	role := roles[0]
+
+	log.Debug("Got user role: %+v", role)
+
+	d.SetId(role.RoleID)
+	d.Set("roleid", role.RoleID)
+	d.Set("name", role.Name)
+	d.Set("type", USER_ROLE_TYPES_REV[role.Type])
+	d.Set("readonly", role.ReadOnly == "1")
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// usergroupObject zabbix user group, not modelled by the library
+type usergroupObject struct {
+	UserGroupID string `json:"usrgrpid,omitempty"`
+	Name        string `json:"name,omitempty"`
+	UsersStatus string `json:"users_status,omitempty"`
+}
+
+// dataUsergroup terraform user group data source entrypoint
+func dataUsergroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUsergroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"usergroupid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "User group ID",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "User group name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Users of the group are enabled",
+			},
+		},
+	}
+}
+
+// dataUsergroupRead read handler for data resource
+func dataUsergroupRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"filter": map[string]interface{}{},
+	}
+
+	if v, ok := d.GetOk("usergroupid"); ok {
+		params["usrgrpids"] = v
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params["filter"].(map[string]interface{})["name"] = v
+	}
+
+	if params["usrgrpids"] == nil && len(params["filter"].(map[string]interface{})) < 1 {
+		return errors.New("no user group lookup attribute")
+	}
+	log.Debug("performing data lookup with params: %#v", params)
+
+	groups, err := usergroupsGet(api, params)
+	if err != nil {
+		return err
+	}
+
+	if len(groups) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(groups) > 1 {
+		return errors.New("multiple user groups found")
+	}
+	group := groups[0]
+
+	log.Debug("Got user group: %+v", group)
+
+	d.SetId(group.UserGroupID)
+	d.Set("usergroupid", group.UserGroupID)
+	d.Set("name", group.Name)
+	d.Set("enabled", group.UsersStatus == "0")
+
+	return nil
+}
+
+// usergroupsGet fetch user groups
+func usergroupsGet(api *zabbix.API, params zabbix.Params) (groups []usergroupObject, err error) {
+	if _, ok := params["output"]; !ok {
+		params["output"] = "extend"
+	}
+	err = apiGet(api, "usergroup.get", params, &groups)
+	return
+}
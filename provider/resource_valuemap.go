@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// valuemapObject zabbix value map, not modelled by the library
+type valuemapObject struct {
+	ValuemapID string            `json:"valuemapid,omitempty"`
+	HostID     string            `json:"hostid,omitempty"`
+	Name       string            `json:"name"`
+	Mappings   []valuemapMapping `json:"mappings,omitempty"`
+}
+
+// valuemapMapping single value map entry
+type valuemapMapping struct {
+	Value    string `json:"value"`
+	NewValue string `json:"newvalue"`
+}
+
+// dataValuemap terraform value map data source entrypoint
+func dataValuemap() *schema.Resource {
+	return &schema.Resource{
+		Read: dataValuemapRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Value map name",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"hostid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Host or template owning the value map, zabbix 5.4+",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9]+$"), "must be numeric"),
+			},
+			"mapping": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"newvalue": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Value mappings",
+			},
+		},
+	}
+}
+
+// dataValuemapRead read handler for data resource
+func dataValuemapRead(d *schema.ResourceData, m interface{}) error {
+	api := m.(*providerMeta).API
+
+	params := zabbix.Params{
+		"output":         "extend",
+		"selectMappings": "extend",
+		"filter": map[string]interface{}{
+			"name": d.Get("name"),
+		},
+	}
+
+	if v, ok := d.GetOk("hostid"); ok {
+		version, err := apiVersion(api)
+		if err != nil {
+			return err
+		}
+		if !version.AtLeast(5, 4) {
+			return errors.New("value maps belong to hosts from zabbix 5.4, hostid is not supported")
+		}
+		params["hostids"] = v
+	}
+
+	log.Debug("performing data lookup with params: %#v", params)
+
+	var valuemaps []valuemapObject
+	if err := api.CallWithErrorParse("valuemap.get", params, &valuemaps); err != nil {
+		return err
+	}
+
+	if len(valuemaps) < 1 {
+		d.SetId("")
+		return nil
+	}
+	if len(valuemaps) > 1 {
+		return errors.New("multiple value maps found, set hostid")
+	}
+	valuemap := valuemaps[0]
+
+	log.Debug("Got value map: %+v", valuemap)
+
+	mappings := make([]interface{}, len(valuemap.Mappings))
+	for i, v := range valuemap.Mappings {
+		mappings[i] = map[string]interface{}{
+			"value":    v.Value,
+			"newvalue": v.NewValue,
+		}
+	}
+
+	d.SetId(valuemap.ValuemapID)
+	d.Set("name", valuemap.Name)
+	d.Set("hostid", valuemap.HostID)
+	d.Set("mapping", mappings)
+
+	return nil
+}
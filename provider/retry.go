@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// database errors reported by the api after the transaction was rolled back, safe to retry
+var API_RETRY_ERRORS = []string{
+	"Deadlock found",             // mysql
+	"Lock wait timeout exceeded", // mysql
+	"deadlock detected",          // postgresql
+	"could not serialize access", // postgresql
+}
+
+// apiRetryable check if an api error is transient
+func apiRetryable(err error) bool {
+	msg := err.Error()
+	for _, v := range API_RETRY_ERRORS {
+		if strings.Contains(msg, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiRetry run an api call, retrying transient errors with jittered exponential backoff
+func apiRetry(m interface{}, call func() error) error {
+	meta := m.(*providerMeta)
+
+	wait := meta.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := call()
+		readCacheClear(meta.API)
+		if err == nil || attempt >= meta.Retries || !apiRetryable(err) {
+			return err
+		}
+
+		// spread retries of parallel resources hitting the same lock
+		sleep := wait/2 + time.Duration(rand.Int63n(int64(wait)+1))
+		log.Debug("transient api error, retrying in %s: %s", sleep, err)
+		time.Sleep(sleep)
+
+		wait *= 2
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// search arguments shared by single object data sources
+var searchSchema = map[string]*schema.Schema{
+	"search": &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Partial match of attribute names to values, combined with the exact lookup attributes",
+	},
+	"wildcards_enabled": &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Allow * wildcards in search values",
+	},
+	"sortfield": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Attribute to sort matches by",
+	},
+	"sortorder": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "ASC",
+		ValidateFunc: validation.StringInSlice([]string{"ASC", "DESC"}, false),
+		Description:  "Sort order, one of: ASC, DESC",
+	},
+	"pick_first": &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Use the first match by sortfield instead of failing on multiple matches",
+	},
+}
+
+// searchParams add the search arguments to get params, idField breaks ties without a sortfield
+func searchParams(d *schema.ResourceData, params zabbix.Params, idField string) {
+	if v := d.Get("search").(map[string]interface{}); len(v) > 0 {
+		params["search"] = v
+		params["searchWildcardsEnabled"] = d.Get("wildcards_enabled").(bool)
+	}
+
+	sortfield := d.Get("sortfield").(string)
+	if sortfield != "" {
+		params["sortfield"] = sortfield
+		params["sortorder"] = d.Get("sortorder").(string)
+	}
+
+	if d.Get("pick_first").(bool) {
+		if sortfield == "" {
+			params["sortfield"] = idField
+			params["sortorder"] = d.Get("sortorder").(string)
+		}
+		params["limit"] = 1
+	}
+}
+
+// searchEmpty no exact lookup nor search given
+func searchEmpty(d *schema.ResourceData, params zabbix.Params) bool {
+	filter, _ := params["filter"].(map[string]interface{})
+	return len(filter) < 1 && len(d.Get("search").(map[string]interface{})) < 1
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// sessionCache on disk session details, only reused for the same url and user
+type sessionCache struct {
+	Url      string `json:"url"`
+	Username string `json:"username"`
+	Session  string `json:"session"`
+}
+
+// sessionCacheLoad load a cached session id, empty if missing or for another url/user
+func sessionCacheLoad(file, url, username string) string {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn("unable to read session cache %s: %s", file, err)
+		}
+		return ""
+	}
+
+	var cache sessionCache
+	if err := json.Unmarshal(b, &cache); err != nil {
+		log.Warn("unable to parse session cache %s: %s", file, err)
+		return ""
+	}
+
+	if cache.Url != url || cache.Username != username {
+		return ""
+	}
+
+	return cache.Session
+}
+
+// sessionCacheSave persist a session id, failures only warn as a fresh login still works
+func sessionCacheSave(file, url, username, session string) {
+	b, err := json.Marshal(sessionCache{Url: url, Username: username, Session: session})
+	if err != nil {
+		log.Warn("unable to encode session cache: %s", err)
+		return
+	}
+
+	if err := ioutil.WriteFile(file, b, 0600); err != nil {
+		log.Warn("unable to write session cache %s: %s", file, err)
+	}
+}
+
+// sessionValid check a session id is still active, extending it
+func sessionValid(api *zabbix.API, session string) bool {
+	_, err := api.CallWithError("user.checkAuthentication", zabbix.Params{
+		"sessionid": session,
+	})
+
+	if err != nil {
+		log.Debug("cached session is no longer valid: %s", err)
+		return false
+	}
+
+	return true
+}
+
+// sessionLogin log in with username and password, using the login parameter name of the server version
+// zabbix 5.4 renamed user to username, the old name was removed in 6.4
+func sessionLogin(api *zabbix.API, version serverVersion, username, password string) (string, error) {
+	field := "user"
+	if version.AtLeast(5, 4) {
+		field = "username"
+	}
+
+	response, err := api.CallWithError("user.login", zabbix.Params{
+		field:      username,
+		"password": password,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	session, ok := response.Result.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected user.login result %#v", response.Result)
+	}
+	api.Auth = session
+
+	return session, nil
+}
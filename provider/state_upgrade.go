@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// current state schema version of versioned resources
+const STATE_SCHEMA_VERSION = 1
+
+// stateVersioned set the schema version of a resource, registering the upgrade from version 0
+//
+// version 0 states predate versioning, every attribute since has been added rather
+// than renamed, so the current schema shape is used to decode them
+func stateVersioned(r *schema.Resource, upgrade schema.StateUpgradeFunc) *schema.Resource {
+	v0 := &schema.Resource{Schema: r.Schema}
+
+	r.SchemaVersion = STATE_SCHEMA_VERSION
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    v0.CoreConfigSchema().ImpliedType(),
+			Upgrade: stateUpgradeDefaults(r.Schema, upgrade),
+		},
+	}
+
+	return r
+}
+
+// stateUpgradeDefaults wrap an upgrade, filling schema defaults for attributes missing from the old state
+func stateUpgradeDefaults(s map[string]*schema.Schema, upgrade schema.StateUpgradeFunc) schema.StateUpgradeFunc {
+	return func(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		if upgrade != nil {
+			var err error
+			if rawState, err = upgrade(rawState, meta); err != nil {
+				return nil, err
+			}
+		}
+
+		stateFillDefaults(s, rawState)
+		return rawState, nil
+	}
+}
+
+// stateFillDefaults set defaults for attributes not present in a raw state, recursing into list blocks
+func stateFillDefaults(s map[string]*schema.Schema, rawState map[string]interface{}) {
+	for k, v := range s {
+		if v.Computed && !v.Optional {
+			continue
+		}
+		cur, ok := rawState[k]
+
+		if (!ok || cur == nil) && v.Default != nil {
+			log.Debug("state upgrade, setting %s to default %v", k, v.Default)
+			rawState[k] = v.Default
+			continue
+		}
+
+		elem, isResource := v.Elem.(*schema.Resource)
+		if !isResource || v.Type != schema.TypeList {
+			continue
+		}
+		list, _ := cur.([]interface{})
+		for _, e := range list {
+			if block, ok := e.(map[string]interface{}); ok {
+				stateFillDefaults(elem.Schema, block)
+			}
+		}
+	}
+}
+
+// hostStateUpgradeV0 host interfaces prior to useip always connected by ip when one was given
+func hostStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	list, _ := rawState["interface"].([]interface{})
+	for _, e := range list {
+		block, ok := e.(map[string]interface{})
+		if !ok || block["useip"] != nil {
+			continue
+		}
+		ip, _ := block["ip"].(string)
+		block["useip"] = ip != ""
+	}
+	return rawState, nil
+}
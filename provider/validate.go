@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var timePeriodRegexp = regexp.MustCompile("^([1-7])(?:-([1-7]))?,([0-9]{1,2}):([0-9]{2})-([0-9]{1,2}):([0-9]{2})$")
+
+// timePeriodParse check a single zabbix time period, d[-d],hh:mm-hh:mm
+func timePeriodParse(period string) error {
+	match := timePeriodRegexp.FindStringSubmatch(period)
+	if match == nil {
+		return fmt.Errorf("%q is not a time period, expected d[-d],hh:mm-hh:mm", period)
+	}
+
+	n := make([]int, len(match))
+	for i := 1; i < len(match); i++ {
+		n[i], _ = strconv.Atoi(match[i])
+	}
+	if match[2] == "" {
+		n[2] = n[1]
+	}
+
+	switch {
+	case n[1] > n[2]:
+		return fmt.Errorf("%q: first day is after the last day", period)
+	case n[3] > 23 || n[4] > 59 || n[6] > 59:
+		return fmt.Errorf("%q: invalid time", period)
+	case n[5] > 24 || (n[5] == 24 && n[6] != 0):
+		return fmt.Errorf("%q: end time is after 24:00", period)
+	case n[3]*60+n[4] >= n[5]*60+n[6]:
+		return fmt.Errorf("%q: start time is not before end time", period)
+	}
+
+	return nil
+}
+
+// validateTimePeriod ValidateFunc for time periods, several may be separated by ';', user macros are not checked
+func validateTimePeriod(v interface{}, k string) (ws []string, es []error) {
+	value, ok := v.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	for _, period := range strings.Split(strings.TrimSuffix(value, ";"), ";") {
+		if strings.Contains(period, "{$") {
+			continue
+		}
+		if err := timePeriodParse(period); err != nil {
+			es = append(es, fmt.Errorf("%s: %s", k, err))
+		}
+	}
+
+	return
+}
+
+// validateItemDelay ValidateFunc for item update intervals, checking the periods of flexible intervals
+func validateItemDelay(v interface{}, k string) (ws []string, es []error) {
+	value, ok := v.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+	if strings.TrimSpace(value) == "" {
+		es = append(es, fmt.Errorf("%s must not be empty", k))
+		return
+	}
+
+	// the first part is the interval, followed by flexible (interval/period) or scheduling intervals
+	for _, part := range strings.Split(value, ";")[1:] {
+		i := strings.Index(part, "/")
+		if i < 0 || strings.Contains(part, "{$") {
+			continue
+		}
+		_, errs := validateTimePeriod(part[i+1:], fmt.Sprintf("%s flexible interval %q", k, part))
+		es = append(es, errs...)
+	}
+
+	return
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tpretz/go-zabbix-api"
+)
+
+// serverVersion zabbix api version, major.minor
+type serverVersion struct {
+	Major int
+	Minor int
+}
+
+// String format as reported by the api
+func (v serverVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// AtLeast check the version is at or above major.minor
+func (v serverVersion) AtLeast(major, minor int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+// parseServerVersion parse an api version string, e.g. 6.0.12
+func parseServerVersion(str string) (v serverVersion, err error) {
+	parts := strings.Split(str, ".")
+	if len(parts) < 2 {
+		err = fmt.Errorf("unable to parse zabbix version %s", str)
+		return
+	}
+
+	if v.Major, err = strconv.Atoi(parts[0]); err != nil {
+		return
+	}
+	v.Minor, err = strconv.Atoi(parts[1])
+
+	return
+}
+
+var versionCache = map[*zabbix.API]serverVersion{}
+var versionLock sync.Mutex
+
+// apiVersion fetch the server version, cached per api connection
+func apiVersion(api *zabbix.API) (serverVersion, error) {
+	versionLock.Lock()
+	defer versionLock.Unlock()
+
+	if v, ok := versionCache[api]; ok {
+		return v, nil
+	}
+
+	str, err := api.Version()
+	if err != nil {
+		return serverVersion{}, err
+	}
+
+	v, err := parseServerVersion(str)
+	if err != nil {
+		return v, err
+	}
+
+	log.Debug("detected zabbix api version %s", v)
+	versionCache[api] = v
+
+	return v, nil
+}